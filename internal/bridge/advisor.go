@@ -0,0 +1,217 @@
+package bridge
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"sync"
+
+	tally "github.com/uber-go/tally/v4"
+)
+
+const defaultSuggestedBuckets = 15
+
+type (
+	// BucketAdvisor keeps a compact sketch of the raw values recorded to each
+	// histogram created by a MeterProvider alongside the recording to Tally.
+	// From these it reports how well each histogram's buckets fit the
+	// observed values and suggests better ones. Attach a BucketAdvisor to a
+	// MeterProvider with WithBucketAdvisor.
+	BucketAdvisor struct {
+		suggested int
+
+		mu      sync.Mutex
+		tracked map[matchKey]*histogramTracker
+		order   []matchKey
+	}
+
+	// BucketReport describes the values observed by a single histogram and
+	// the fit of its buckets. Values and bucket boundaries are given in the
+	// histogram's unit.
+	BucketReport struct {
+		Meter      string `json:"meter"`
+		Instrument string `json:"instrument"`
+		Unit       string `json:"unit,omitempty"`
+
+		Count uint64  `json:"count"`
+		Sum   float64 `json:"sum"`
+		Min   float64 `json:"min"`
+		Max   float64 `json:"max"`
+		P50   float64 `json:"p50"`
+		P90   float64 `json:"p90"`
+		P99   float64 `json:"p99"`
+		P999  float64 `json:"p999"`
+
+		// Buckets are the boundaries of the histogram's current buckets.
+		Buckets []float64 `json:"buckets"`
+
+		// UnderflowPercent is the percentage of values that fell at or below
+		// the lowest boundary, i.e. in the bucket with no lower bound.
+		UnderflowPercent float64 `json:"underflow_percent"`
+
+		// OverflowPercent is the percentage of values that fell above the
+		// highest boundary, i.e. in the bucket with no upper bound.
+		OverflowPercent float64 `json:"overflow_percent"`
+
+		// SuggestedBuckets are boundaries spanning the observed values, fit
+		// for use with ExplicitBuckets or a HistogramBucketer.
+		SuggestedBuckets []float64 `json:"suggested_buckets"`
+	}
+
+	histogramTracker struct {
+		desc   Descriptor
+		sketch *sketch
+
+		mu              sync.Mutex
+		bounds          []float64
+		underflow, over uint64
+	}
+)
+
+// NewBucketAdvisor instantiates a BucketAdvisor that suggests layouts of the
+// provided number of buckets. A default of 15 is used if suggestedBuckets is
+// not positive.
+func NewBucketAdvisor(suggestedBuckets int) *BucketAdvisor {
+	if suggestedBuckets <= 0 {
+		suggestedBuckets = defaultSuggestedBuckets
+	}
+	return &BucketAdvisor{
+		suggested: suggestedBuckets,
+		tracked:   make(map[matchKey]*histogramTracker),
+	}
+}
+
+// track gives the tracker for the described histogram, which is shared by all
+// histograms of the same Meter, name and unit. The tracker's buckets are
+// replaced by those provided.
+func (a *BucketAdvisor) track(
+	desc Descriptor,
+	buckets tally.Buckets,
+) *histogramTracker {
+	key := matchKey{meter: desc.MeterName(), name: desc.Name(), unit: desc.Unit()}
+	a.mu.Lock()
+	t, ok := a.tracked[key]
+	if !ok {
+		t = &histogramTracker{desc: desc, sketch: newSketch()}
+		a.tracked[key] = t
+		a.order = append(a.order, key)
+	}
+	a.mu.Unlock()
+
+	bounds := buckets.AsValues()
+	if per, ok := durationUnits[desc.Unit()]; ok {
+		bounds = bounds[:0:0]
+		for _, d := range buckets.AsDurations() {
+			bounds = append(bounds, float64(d)/float64(per))
+		}
+	}
+	t.mu.Lock()
+	t.bounds = bounds
+	t.mu.Unlock()
+	return t
+}
+
+func (t *histogramTracker) observe(value float64) {
+	t.sketch.add(value)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.bounds) == 0 {
+		return
+	}
+	if value <= t.bounds[0] {
+		t.underflow++
+	} else if value > t.bounds[len(t.bounds)-1] {
+		t.over++
+	}
+}
+
+// Report gives a BucketReport for each histogram tracked by this
+// BucketAdvisor in the order in which the histograms were first created.
+func (a *BucketAdvisor) Report() []BucketReport {
+	a.mu.Lock()
+	trackers := make([]*histogramTracker, 0, len(a.order))
+	for _, key := range a.order {
+		trackers = append(trackers, a.tracked[key])
+	}
+	a.mu.Unlock()
+
+	reports := make([]BucketReport, 0, len(trackers))
+	for _, t := range trackers {
+		reports = append(reports, t.report(a.suggested))
+	}
+	return reports
+}
+
+// WriteJSON writes the output of Report to the provided io.Writer as a JSON
+// array.
+func (a *BucketAdvisor) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(a.Report())
+}
+
+func (t *histogramTracker) report(suggested int) BucketReport {
+	t.mu.Lock()
+	r := BucketReport{
+		Meter:      t.desc.MeterName(),
+		Instrument: t.desc.Name(),
+		Unit:       t.desc.Unit(),
+		Buckets:    append([]float64(nil), t.bounds...),
+	}
+	underflow, over := t.underflow, t.over
+	t.mu.Unlock()
+
+	s := t.sketch
+	s.mu.Lock()
+	r.Count, r.Sum = s.count, s.sum
+	min, max := s.min, s.max
+	s.mu.Unlock()
+	if r.Count == 0 {
+		return r
+	}
+	r.Min, r.Max = min, max
+	q := s.quantiles(0.01, 0.5, 0.9, 0.99, 0.999)
+	r.P50, r.P90, r.P99, r.P999 = q[1], q[2], q[3], q[4]
+	r.UnderflowPercent = 100 * float64(underflow) / float64(r.Count)
+	r.OverflowPercent = 100 * float64(over) / float64(r.Count)
+	r.SuggestedBuckets = suggestBuckets(q[0], min, max, suggested)
+	return r
+}
+
+// suggestBuckets gives up to n exponentially spaced boundaries, rounded up to
+// two significant figures, running from lo (typically the 1st percentile) to
+// max. A zero boundary is prepended if non-positive values were observed.
+func suggestBuckets(lo, min, max float64, n int) []float64 {
+	var bounds []float64
+	if min <= 0 {
+		bounds = append(bounds, 0)
+	}
+	if max <= 0 {
+		return bounds
+	}
+	if lo <= 0 {
+		// cover three orders of magnitude below the maximum
+		lo = max / 1000
+	}
+	factor := 1.0
+	if n > 1 && max > lo {
+		factor = math.Pow(max/lo, 1/float64(n-1))
+	}
+	for i, v := 0, lo; i < n; i, v = i+1, v*factor {
+		b := roundUpSignificant(v)
+		if len(bounds) == 0 || b > bounds[len(bounds)-1] {
+			bounds = append(bounds, b)
+		}
+	}
+	return bounds
+}
+
+// roundUpSignificant rounds a positive value up to two significant figures.
+func roundUpSignificant(v float64) float64 {
+	mag := math.Pow(10, math.Floor(math.Log10(v))-1)
+	rounded := math.Ceil(v/mag-1e-9) * mag
+	// trim floating point noise, e.g. 0.30000000000000004
+	trimmed, _ := strconv.ParseFloat(strconv.FormatFloat(rounded, 'g', 2, 64), 64)
+	return trimmed
+}
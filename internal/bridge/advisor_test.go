@@ -0,0 +1,94 @@
+package bridge_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestBucketAdvisor(t *testing.T) {
+	t.Parallel()
+	advisor := bridge.NewBucketAdvisor(5)
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithBucketAdvisor(advisor),
+		bridge.WithHistogramBucketer(buckets))
+	m := mp.Meter("m")
+
+	hist := must(m.Int64Histogram("h"))
+	for i := int64(0); i < 100; i++ {
+		hist.Record(context.TODO(), i)
+	}
+	must(m.Float64Histogram("latency", metric.WithUnit("ms"),
+		metric.WithExplicitBucketBoundaries(10, 20))).
+		Record(context.TODO(), 15)
+	must(m.Float64Histogram("unused"))
+
+	hsnap, ok := scope.Snapshot().Histograms()["scope.m.h+"]
+	require.True(t, ok, "values still recorded to tally")
+	require.EqualValues(t, 95, hsnap.Values()[1.7976931348623157e+308])
+
+	reports := advisor.Report()
+	require.Len(t, reports, 3)
+
+	r := reports[0]
+	require.Equal(t, "m", r.Meter)
+	require.Equal(t, "h", r.Instrument)
+	require.EqualValues(t, 100, r.Count)
+	require.EqualValues(t, 4950, r.Sum)
+	require.EqualValues(t, 0, r.Min)
+	require.EqualValues(t, 99, r.Max)
+	require.InEpsilon(t, 49, r.P50, 0.01)
+	require.InEpsilon(t, 98, r.P99, 0.01)
+	require.Equal(t, []float64{0, 1, 2, 3, 4}, r.Buckets)
+	require.EqualValues(t, 1, r.UnderflowPercent)
+	require.EqualValues(t, 95, r.OverflowPercent)
+	require.Len(t, r.SuggestedBuckets, 6, "zero bucket prepended")
+	require.Zero(t, r.SuggestedBuckets[0])
+	require.GreaterOrEqual(t, r.SuggestedBuckets[5], 99.0)
+	require.IsIncreasing(t, r.SuggestedBuckets)
+
+	r = reports[1]
+	require.Equal(t, "ms", r.Unit)
+	require.Equal(t, []float64{10, 20}, r.Buckets, "durations in histogram unit")
+	require.Zero(t, r.OverflowPercent)
+
+	r = reports[2]
+	require.Zero(t, r.Count)
+	require.Empty(t, r.SuggestedBuckets)
+
+	var buf bytes.Buffer
+	require.NoError(t, advisor.WriteJSON(&buf))
+	var decoded []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded, 3)
+	require.EqualValues(t, 95, decoded[0]["overflow_percent"])
+}
+
+func TestBucketAdvisorWideRange(t *testing.T) {
+	t.Parallel()
+	advisor := bridge.NewBucketAdvisor(0)
+	mp := bridge.NewMeterProvider(tally.NewTestScope("scope", nil),
+		bridge.WithBucketAdvisor(advisor))
+	hist := must(mp.Meter("m").Float64Histogram("h"))
+
+	// spans more orders of magnitude than the sketch has bins for
+	v := 1e-10
+	for i := 0; i < 1000; i++ {
+		hist.Record(context.TODO(), v)
+		v *= 1.05
+	}
+
+	r := advisor.Report()[0]
+	require.EqualValues(t, 1000, r.Count)
+	require.InEpsilon(t, 1e-10*math.Pow(1.05, 989), r.P99, 0.01,
+		"high quantiles unaffected by merged bins")
+	require.Len(t, r.SuggestedBuckets, 15)
+}
@@ -0,0 +1,189 @@
+package bridge
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ErrInvalidAttributeView is a base error cause returned when AttributeViews
+// are constructed with a view that has an invalid pattern.
+var ErrInvalidAttributeView = errors.New("invalid attribute view")
+
+type (
+	// ValueMapper rewrites the value of an attribute, as emitted by
+	// attribute.Value.Emit, before it is used as a Tally tag value.
+	ValueMapper func(string) string
+
+	// AttributeView describes how the attributes recorded to instruments
+	// whose Meter and instrument names match are turned into Tally tags.
+	// Attributes are first filtered by Allow and Deny, then have their values
+	// mapped by Values and finally have their keys renamed by Rename. If
+	// renaming maps two attributes to the same tag key then the value of the
+	// attribute whose original key sorts last is used.
+	AttributeView struct {
+		// Name identifies this view in errors.
+		Name string
+
+		// Meter is matched against the name of the Meter that created the
+		// instrument.
+		Meter Pattern
+
+		// Instrument is matched against the name of the instrument.
+		Instrument Pattern
+
+		// Allow, if not empty, lists the only attribute keys kept.
+		Allow []attribute.Key
+
+		// Deny lists attribute keys that are dropped.
+		Deny []attribute.Key
+
+		// Values maps attribute keys to funcs that rewrite their values.
+		Values map[attribute.Key]ValueMapper
+
+		// Rename maps attribute keys to the Tally tag keys used for them.
+		Rename map[attribute.Key]string
+	}
+
+	attributeView struct {
+		meter      *regexp.Regexp
+		instrument *regexp.Regexp
+		allow      map[attribute.Key]struct{}
+		deny       map[attribute.Key]struct{}
+		values     map[attribute.Key]ValueMapper
+		rename     map[attribute.Key]string
+	}
+
+	// AttributeViews chooses, for each instrument, the first AttributeView
+	// matching its Meter and instrument names. The attributes of instruments
+	// matching no view are passed to Tally verbatim. Pass AttributeViews to a
+	// MeterProvider with WithAttributeViews.
+	AttributeViews struct {
+		views []*attributeView
+	}
+)
+
+// ValueLookup creates a ValueMapper that replaces values found in the
+// provided table and leaves all other values unchanged.
+func ValueLookup(table map[string]string) ValueMapper {
+	lookup := make(map[string]string, len(table))
+	for k, v := range table {
+		lookup[k] = v
+	}
+	return func(value string) string {
+		if mapped, ok := lookup[value]; ok {
+			return mapped
+		}
+		return value
+	}
+}
+
+// NewAttributeViews instantiates AttributeViews that evaluate the provided
+// views in order. An error satisfying errors.Is(err, ErrInvalidAttributeView)
+// is returned if any view has an invalid pattern.
+func NewAttributeViews(views ...AttributeView) (*AttributeViews, error) {
+	av := &AttributeViews{views: make([]*attributeView, 0, len(views))}
+	for i, view := range views {
+		compiled, err := compileView(view)
+		if err != nil {
+			return nil, fmt.Errorf("%w %d (%q): %v",
+				ErrInvalidAttributeView, i, view.Name, err)
+		}
+		av.views = append(av.views, compiled)
+	}
+	return av, nil
+}
+
+func compileView(view AttributeView) (*attributeView, error) {
+	meter, err := view.Meter.compile()
+	if err != nil {
+		return nil, fmt.Errorf("meter pattern: %w", err)
+	}
+	instrument, err := view.Instrument.compile()
+	if err != nil {
+		return nil, fmt.Errorf("instrument pattern: %w", err)
+	}
+	v := &attributeView{
+		meter:      meter,
+		instrument: instrument,
+		deny:       keySet(view.Deny),
+		values:     make(map[attribute.Key]ValueMapper, len(view.Values)),
+		rename:     make(map[attribute.Key]string, len(view.Rename)),
+	}
+	if len(view.Allow) > 0 {
+		v.allow = keySet(view.Allow)
+	}
+	for k, f := range view.Values {
+		v.values[k] = f
+	}
+	for k, renamed := range view.Rename {
+		v.rename[k] = renamed
+	}
+	return v, nil
+}
+
+func keySet(keys []attribute.Key) map[attribute.Key]struct{} {
+	set := make(map[attribute.Key]struct{}, len(keys))
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+	return set
+}
+
+// match gives the first view matching the described instrument or nil if
+// there is none. It is safe to call on nil AttributeViews.
+func (av *AttributeViews) match(desc Descriptor) *attributeView {
+	if av == nil {
+		return nil
+	}
+	for _, v := range av.views {
+		if v.matches(desc) {
+			return v
+		}
+	}
+	return nil
+}
+
+func (v *attributeView) matches(desc Descriptor) bool {
+	if v.meter != nil && !v.meter.MatchString(desc.MeterName()) {
+		return false
+	}
+	return v.instrument == nil || v.instrument.MatchString(desc.Name())
+}
+
+// apply gives the attribute set as processed by this view: attributes that
+// are not kept are dropped, values are mapped and keys renamed. A nil view
+// gives the attribute set unchanged.
+func (v *attributeView) apply(attrs attribute.Set) attribute.Set {
+	if v == nil || attrs.Len() == 0 {
+		return attrs
+	}
+	kvs := make([]attribute.KeyValue, 0, attrs.Len())
+	for iter := attrs.Iter(); iter.Next(); {
+		kv := iter.Attribute()
+		if !v.keep(kv.Key) {
+			continue
+		}
+		if f, ok := v.values[kv.Key]; ok {
+			kv.Value = attribute.StringValue(f(kv.Value.Emit()))
+		}
+		if renamed, ok := v.rename[kv.Key]; ok {
+			kv.Key = attribute.Key(renamed)
+		}
+		kvs = append(kvs, kv)
+	}
+	return attribute.NewSet(kvs...)
+}
+
+func (v *attributeView) keep(key attribute.Key) bool {
+	if _, denied := v.deny[key]; denied {
+		return false
+	}
+	if v.allow == nil {
+		return true
+	}
+	_, allowed := v.allow[key]
+	return allowed
+}
@@ -0,0 +1,129 @@
+package bridge_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestAttributeViews(t *testing.T) {
+	t.Parallel()
+	views, err := bridge.NewAttributeViews(
+		bridge.AttributeView{
+			Name:       "http",
+			Meter:      bridge.Glob("net/*"),
+			Instrument: bridge.Glob("http.*"),
+			Allow:      []attribute.Key{"http.method", "http.status_code"},
+			Rename: map[attribute.Key]string{
+				"http.method":      "method",
+				"http.status_code": "code",
+			},
+			Values: map[attribute.Key]bridge.ValueMapper{
+				"http.method": strings.ToLower,
+				"http.status_code": bridge.ValueLookup(map[string]string{
+					"200": "ok",
+				}),
+			},
+		},
+		bridge.AttributeView{
+			Name:  "no-user",
+			Meter: bridge.Glob("net/*"),
+			Deny:  []attribute.Key{"user"},
+		},
+	)
+	require.NoError(t, err)
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithScopeNameSeparator("/"),
+		bridge.WithAttributeViews(views))
+
+	requests := must(mp.Meter("net/http").Int64Counter("http.requests"))
+	for _, user := range []string{"a", "b"} {
+		requests.Add(context.TODO(), 1, metric.WithAttributes(
+			attribute.String("http.method", "GET"),
+			attribute.Int("http.status_code", 200),
+			attribute.String("user", user)))
+	}
+	requests.Add(context.TODO(), 1, metric.WithAttributes(
+		attribute.String("http.method", "PUT"),
+		attribute.Int("http.status_code", 404)))
+	requests.Add(context.TODO(), 1, metric.WithAttributes(
+		attribute.String("user", "a")))
+
+	userKVs := metric.WithAttributes(attribute.String("user", "a"),
+		attribute.String("host", "h"))
+	must(mp.Meter("net/dns").Int64Counter("lookups")).
+		Add(context.TODO(), 1, userKVs)
+	must(mp.Meter("other").Int64Counter("c")).Add(context.TODO(), 1, userKVs)
+
+	must(mp.Meter("net/dns").Int64ObservableGauge("cache",
+		metric.WithInt64Callback(
+			func(_ context.Context, o metric.Int64Observer) error {
+				o.Observe(5, userKVs)
+				return nil
+			})))
+	mp.Collect(context.TODO())
+
+	snap := scope.Snapshot()
+	counters := snap.Counters()
+	require.EqualValues(t, 2,
+		counters["scope.net.http.http.requests+code=ok,method=get"].Value(),
+		"attribute sets mapping to the same tags share a series")
+	require.EqualValues(t, 1,
+		counters["scope.net.http.http.requests+code=404,method=put"].Value())
+	require.EqualValues(t, 1, counters["scope.net.http.http.requests+"].Value(),
+		"all attributes filtered out")
+	require.EqualValues(t, 1, counters["scope.net.dns.lookups+host=h"].Value())
+	require.EqualValues(t, 1, counters["scope.other.c+host=h,user=a"].Value(),
+		"no matching view")
+	require.EqualValues(t, 5,
+		snap.Gauges()["scope.net.dns.cache+host=h"].Value(),
+		"views apply to async instruments")
+}
+
+func TestAttributeViewsBeforeCardinalityLimit(t *testing.T) {
+	t.Parallel()
+	views, err := bridge.NewAttributeViews(bridge.AttributeView{
+		Name: "no-user",
+		Deny: []attribute.Key{"user"},
+	})
+	require.NoError(t, err)
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithSelfTelemetryScope(tally.NoopScope),
+		bridge.WithAttributeViews(views),
+		bridge.WithCardinalityLimit(2))
+
+	counter := must(mp.Meter("m").Int64Counter("c"))
+	withOTELErrorHandler(panicHandler, func() {
+		for _, user := range []string{"a", "b", "c", "d"} {
+			for _, method := range []string{"get", "put"} {
+				counter.Add(context.TODO(), 1, metric.WithAttributes(
+					attribute.String("method", method),
+					attribute.String("user", user)))
+			}
+		}
+	})
+
+	counters := scope.Snapshot().Counters()
+	require.EqualValues(t, 4, counters["scope.m.c+method=get"].Value())
+	require.EqualValues(t, 4, counters["scope.m.c+method=put"].Value())
+	require.NotContains(t, counters, "scope.m.c+otel_metric_overflow=true",
+		"limit counts attribute sets after the view is applied")
+}
+
+func TestInvalidAttributeView(t *testing.T) {
+	t.Parallel()
+	_, err := bridge.NewAttributeViews(bridge.AttributeView{
+		Name:       "bad",
+		Instrument: bridge.Regexp("("),
+	})
+	require.True(t, errors.Is(err, bridge.ErrInvalidAttributeView))
+}
@@ -0,0 +1,97 @@
+package bridge
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// ErrUnbindableInstrument is returned when an attempt is made to bind an
+// instrument that was not created by a Meter of this bridge.
+var ErrUnbindableInstrument = errors.New("instrument cannot be bound")
+
+type (
+	// BoundInt64Counter is an Int64Counter bound to a fixed attribute set.
+	// Values added are written straight to the Tally counter for that set.
+	BoundInt64Counter interface {
+		Add(value int64)
+	}
+
+	// BoundFloat64Histogram is a Float64Histogram bound to a fixed attribute
+	// set. Values recorded are written straight to the Tally histogram (or
+	// timer) for that set.
+	BoundFloat64Histogram interface {
+		Record(value float64)
+	}
+
+	boundCounter struct {
+		desc Descriptor
+		ctr  tally.Counter
+	}
+
+	boundHistogram[N Number] struct {
+		hist   *Histogram[N]
+		series histogramSeries
+	}
+
+	boundTimer struct {
+		per   time.Duration
+		timer tally.Timer
+	}
+)
+
+// BindInt64Counter resolves the Tally counter for the provided attribute set
+// once and returns a handle that adds to it directly. The handle shares its
+// Tally counter with recordings made through the Int64Counter with an equal
+// attribute set. An error satisfying errors.Is(err, ErrUnbindableInstrument)
+// is returned if the Int64Counter was not created by this bridge.
+func BindInt64Counter(
+	ctr metric.Int64Counter,
+	attrs attribute.Set,
+) (BoundInt64Counter, error) {
+	c, ok := ctr.(*Counter)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnbindableInstrument, ctr)
+	}
+	return &boundCounter{desc: c.desc, ctr: c.series.get(attrs)}, nil
+}
+
+// BindFloat64Histogram resolves the Tally histogram or timer for the provided
+// attribute set once and returns a handle that records to it directly. The
+// handle shares its Tally instrument, and any companion series or quantile
+// summary, with recordings made through the Float64Histogram with an equal
+// attribute set. An error satisfying errors.Is(err, ErrUnbindableInstrument)
+// is returned if the Float64Histogram was not created by this bridge.
+func BindFloat64Histogram(
+	hist metric.Float64Histogram,
+	attrs attribute.Set,
+) (BoundFloat64Histogram, error) {
+	switch h := hist.(type) {
+	case *Histogram[float64]:
+		return &boundHistogram[float64]{hist: h, series: h.series.get(attrs)}, nil
+	case *Timer[float64]:
+		return &boundTimer{per: h.per, timer: h.series.get(attrs)}, nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrUnbindableInstrument, hist)
+}
+
+func (b *boundCounter) Add(value int64) {
+	if err := validateInt64(b.desc.InstrumentKind(), value); err != nil {
+		otel.Handle(err)
+		return
+	}
+	b.ctr.Inc(value)
+}
+
+func (b *boundHistogram[N]) Record(value float64) {
+	b.hist.recordToSeries(b.series, value)
+}
+
+func (b *boundTimer) Record(value float64) {
+	b.timer.Record(time.Duration(value * float64(b.per)))
+}
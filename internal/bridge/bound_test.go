@@ -0,0 +1,99 @@
+package bridge_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func TestBindInt64Counter(t *testing.T) {
+	// not parallel - uses global OTEL error handler
+	scope := tally.NewTestScope("scope", nil)
+	m := bridge.NewMeterProvider(scope).Meter("m")
+	ctr := must(m.Int64Counter("c"))
+	attrs := attribute.NewSet(attribute.String("k", "v"))
+
+	bound, err := bridge.BindInt64Counter(ctr, attrs)
+	require.NoError(t, err)
+	bound.Add(2)
+	ctr.Add(context.TODO(), 3, metric.WithAttributes(attribute.String("k", "v")))
+	bound.Add(4)
+
+	withOTELErrorHandler(panicHandler, func() {
+		require.Panics(t, func() {
+			bound.Add(-1)
+		}, "bound counters are monotonic")
+	})
+
+	snap := scope.Snapshot().Counters()
+	require.Len(t, snap, 1)
+	require.EqualValues(t, 9, snap["scope.m.c+k=v"].Value())
+
+	_, err = bridge.BindInt64Counter(noop.Int64Counter{}, attrs)
+	require.True(t, errors.Is(err, bridge.ErrUnbindableInstrument))
+}
+
+func TestBindFloat64Histogram(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	m := bridge.NewMeterProvider(scope,
+		bridge.WithHistogramBucketer(buckets),
+		bridge.WithTimerSelector(func(desc bridge.Descriptor) bool {
+			return desc.Name() == "t"
+		}),
+		bridge.WithHistogramCompanions(bridge.CompanionSuffixes{
+			Count: "_count",
+		})).Meter("m")
+	kvs := []attribute.KeyValue{attribute.String("k", "v")}
+	attrs := attribute.NewSet(kvs...)
+
+	hist := must(m.Float64Histogram("h"))
+	bound, err := bridge.BindFloat64Histogram(hist, attrs)
+	require.NoError(t, err)
+	bound.Record(0.5)
+	hist.Record(context.TODO(), 3.5, metric.WithAttributes(kvs...))
+
+	timer := must(m.Float64Histogram("t", metric.WithUnit("s")))
+	boundTimer, err := bridge.BindFloat64Histogram(timer, attrs)
+	require.NoError(t, err)
+	boundTimer.Record(1.5)
+
+	snap := scope.Snapshot()
+	hsnap := snap.Histograms()[key("scope.m.h", kvs)].Values()
+	require.EqualValues(t, 1, hsnap[1])
+	require.EqualValues(t, 1, hsnap[4])
+	require.EqualValues(t, 2, snap.Counters()[key("scope.m.h_count", kvs)].Value(),
+		"companion series shared")
+	require.Equal(t, []time.Duration{1500 * time.Millisecond},
+		snap.Timers()[key("scope.m.t", kvs)].Values())
+
+	_, err = bridge.BindFloat64Histogram(noop.Float64Histogram{}, attrs)
+	require.True(t, errors.Is(err, bridge.ErrUnbindableInstrument))
+}
+
+func BenchmarkBoundCounterAdd(b *testing.B) {
+	m := bridge.NewMeterProvider(tally.NewTestScope("", nil)).Meter("m")
+	bound := must(bridge.BindInt64Counter(must(m.Int64Counter("c")), benchAttrs))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bound.Add(1)
+	}
+}
+
+func BenchmarkBoundHistogramRecord(b *testing.B) {
+	m := bridge.NewMeterProvider(tally.NewTestScope("", nil)).Meter("m")
+	hist := must(m.Float64Histogram("h", metric.WithUnit("ms")))
+	bound := must(bridge.BindFloat64Histogram(hist, benchAttrs))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bound.Record(12.5)
+	}
+}
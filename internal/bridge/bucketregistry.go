@@ -0,0 +1,286 @@
+package bridge
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	tally "github.com/uber-go/tally/v4"
+)
+
+// ErrInvalidBucketRule is a base error cause returned when a BucketRegistry is
+// constructed with a rule that has an invalid pattern or bucket spec.
+var ErrInvalidBucketRule = errors.New("invalid bucket rule")
+
+type (
+	// Pattern matches Meter or instrument names. The zero Pattern matches
+	// every name.
+	Pattern struct {
+		expr string
+		set  bool
+	}
+
+	bucketSpecKind int8
+
+	// BucketSpec declares the bucket boundaries to be used for histograms
+	// matching a BucketRule. Boundaries for histograms with a UCUM unit of
+	// time are taken to be in that unit and converted to
+	// tally.DurationBuckets.
+	BucketSpec struct {
+		kind   bucketSpecKind
+		start  float64
+		step   float64
+		count  int
+		scale  int
+		bounds []float64
+	}
+
+	// BucketRule maps histograms whose Meter name, instrument name and unit
+	// match to a BucketSpec.
+	BucketRule struct {
+		// Name identifies this rule in the output of BucketRegistry.Matches.
+		Name string
+
+		// Meter is matched against the name of the Meter that created the
+		// histogram.
+		Meter Pattern
+
+		// Instrument is matched against the name of the histogram.
+		Instrument Pattern
+
+		// Unit, if not empty, must equal the unit of the histogram.
+		Unit string
+
+		// Buckets gives the buckets for matching histograms.
+		Buckets BucketSpec
+	}
+
+	// BucketMatch records the outcome of choosing buckets for a histogram.
+	BucketMatch struct {
+		// Descriptor describes the histogram for which buckets were chosen.
+		Descriptor Descriptor
+
+		// Rule is the name of the matching rule. It is empty if no rule
+		// matched.
+		Rule string
+
+		// Index is the position of the matching rule within the rules given
+		// to NewBucketRegistry or -1 if the fallback HistogramBucketer was
+		// used.
+		Index int
+	}
+
+	compiledRule struct {
+		name       string
+		meter      *regexp.Regexp
+		instrument *regexp.Regexp
+		unit       string
+		bounds     []float64
+	}
+
+	matchKey struct {
+		meter, name, unit string
+	}
+
+	// BucketRegistry chooses histogram buckets from a list of declarative
+	// rules. The first rule matching a histogram gives its buckets and
+	// histograms matching no rule get their buckets from a fallback
+	// HistogramBucketer. Pass the Bucketer method to WithHistogramBucketer to
+	// use a BucketRegistry with a MeterProvider.
+	BucketRegistry struct {
+		rules    []compiledRule
+		fallback HistogramBucketer
+
+		mu      sync.Mutex
+		seen    map[matchKey]int
+		matches []BucketMatch
+	}
+)
+
+const (
+	explicitBucketSpec bucketSpecKind = iota
+	linearBucketSpec
+	exponentialBucketSpec
+	base2ExponentialBucketSpec
+)
+
+// Glob creates a Pattern from a shell-style glob in which '*' matches any
+// sequence of characters (including separators such as '.' and '/') and '?'
+// matches any single character.
+func Glob(glob string) Pattern {
+	var b strings.Builder
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return Pattern{expr: b.String(), set: true}
+}
+
+// Regexp creates a Pattern from a regular expression in the syntax accepted by
+// the regexp package. The expression must match the whole name.
+func Regexp(expr string) Pattern {
+	return Pattern{expr: expr, set: true}
+}
+
+func (p Pattern) compile() (*regexp.Regexp, error) {
+	if !p.set {
+		return nil, nil
+	}
+	return regexp.Compile("^(?:" + p.expr + ")$")
+}
+
+// LinearBuckets declares count buckets with boundaries starting at start and
+// spaced width apart.
+func LinearBuckets(start, width float64, count int) BucketSpec {
+	return BucketSpec{
+		kind:  linearBucketSpec,
+		start: start,
+		step:  width,
+		count: count,
+	}
+}
+
+// ExponentialBuckets declares count buckets with boundaries starting at start
+// and each subsequent boundary being factor times the previous one.
+func ExponentialBuckets(start, factor float64, count int) BucketSpec {
+	return BucketSpec{
+		kind:  exponentialBucketSpec,
+		start: start,
+		step:  factor,
+		count: count,
+	}
+}
+
+// ExplicitBuckets declares buckets with the provided boundaries.
+func ExplicitBuckets(bounds ...float64) BucketSpec {
+	return BucketSpec{
+		kind:   explicitBucketSpec,
+		bounds: append([]float64(nil), bounds...),
+	}
+}
+
+func (s BucketSpec) boundaries() ([]float64, error) {
+	switch s.kind {
+	case linearBucketSpec:
+		return tally.LinearValueBuckets(s.start, s.step, s.count)
+	case exponentialBucketSpec:
+		return tally.ExponentialValueBuckets(s.start, s.step, s.count)
+	case base2ExponentialBucketSpec:
+		bounds, _, err := Base2ExponentialBoundaries(
+			s.scale, s.start, s.step, s.count)
+		return bounds, err
+	}
+	return s.bounds, nil
+}
+
+// NewBucketRegistry instantiates a BucketRegistry that evaluates the provided
+// rules in order. If fallback is nil then DefaultBucketer is used for
+// histograms that match none of the rules. An error satisfying
+// errors.Is(err, ErrInvalidBucketRule) is returned if any rule has an invalid
+// pattern or bucket spec.
+func NewBucketRegistry(
+	fallback HistogramBucketer,
+	rules ...BucketRule,
+) (*BucketRegistry, error) {
+	if fallback == nil {
+		fallback = DefaultBucketer
+	}
+	r := &BucketRegistry{
+		rules:    make([]compiledRule, 0, len(rules)),
+		fallback: fallback,
+		seen:     make(map[matchKey]int),
+	}
+	for i, rule := range rules {
+		compiled, err := compileRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("%w %d (%q): %v",
+				ErrInvalidBucketRule, i, rule.Name, err)
+		}
+		r.rules = append(r.rules, compiled)
+	}
+	return r, nil
+}
+
+func compileRule(rule BucketRule) (compiledRule, error) {
+	meter, err := rule.Meter.compile()
+	if err != nil {
+		return compiledRule{}, fmt.Errorf("meter pattern: %w", err)
+	}
+	instrument, err := rule.Instrument.compile()
+	if err != nil {
+		return compiledRule{}, fmt.Errorf("instrument pattern: %w", err)
+	}
+	bounds, err := rule.Buckets.boundaries()
+	if err != nil {
+		return compiledRule{}, fmt.Errorf("buckets: %w", err)
+	}
+	return compiledRule{
+		name:       rule.Name,
+		meter:      meter,
+		instrument: instrument,
+		unit:       rule.Unit,
+		bounds:     bounds,
+	}, nil
+}
+
+func (c compiledRule) matches(desc Descriptor) bool {
+	if c.unit != "" && c.unit != desc.Unit() {
+		return false
+	}
+	if c.meter != nil && !c.meter.MatchString(desc.MeterName()) {
+		return false
+	}
+	return c.instrument == nil || c.instrument.MatchString(desc.Name())
+}
+
+// Bucketer is a HistogramBucketer giving the buckets of the first rule
+// matching the described histogram, or those given by the fallback
+// HistogramBucketer if no rule matches.
+func (r *BucketRegistry) Bucketer(desc Descriptor) tally.Buckets {
+	index := -1
+	for i, rule := range r.rules {
+		if rule.matches(desc) {
+			index = i
+			break
+		}
+	}
+	r.record(desc, index)
+	if index < 0 {
+		return r.fallback(desc)
+	}
+	return bucketsFromBoundaries(desc, r.rules[index].bounds)
+}
+
+func (r *BucketRegistry) record(desc Descriptor, index int) {
+	match := BucketMatch{Descriptor: desc, Index: index}
+	if index >= 0 {
+		match.Rule = r.rules[index].name
+	}
+	key := matchKey{meter: desc.MeterName(), name: desc.Name(), unit: desc.Unit()}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if i, ok := r.seen[key]; ok {
+		r.matches[i] = match
+		return
+	}
+	r.seen[key] = len(r.matches)
+	r.matches = append(r.matches, match)
+}
+
+// Matches lists the rule chosen for each distinct histogram for which this
+// BucketRegistry has given buckets, in the order in which the histograms were
+// first created. Histograms created with explicit bucket boundary advice do
+// not consult the HistogramBucketer and so are not listed.
+func (r *BucketRegistry) Matches() []BucketMatch {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]BucketMatch(nil), r.matches...)
+}
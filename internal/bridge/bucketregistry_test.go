@@ -0,0 +1,110 @@
+package bridge_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestBucketRegistry(t *testing.T) {
+	t.Parallel()
+	reg, err := bridge.NewBucketRegistry(buckets,
+		bridge.BucketRule{
+			Name:       "http latency",
+			Meter:      bridge.Glob("net/http*"),
+			Instrument: bridge.Glob("*.duration"),
+			Unit:       "ms",
+			Buckets:    bridge.ExponentialBuckets(1, 10, 4),
+		},
+		bridge.BucketRule{
+			Name:       "sizes",
+			Instrument: bridge.Regexp(`(request|response)_size`),
+			Buckets:    bridge.LinearBuckets(0, 100, 3),
+		},
+		bridge.BucketRule{
+			Name:    "catch-all http",
+			Meter:   bridge.Glob("net/http*"),
+			Buckets: bridge.ExplicitBuckets(1, 2, 3),
+		},
+	)
+	require.NoError(t, err)
+
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithHistogramBucketer(reg.Bucketer),
+		bridge.WithScopeNameSeparator("/"))
+	http := mp.Meter("net/http/server")
+	other := mp.Meter("other")
+
+	must(http.Int64Histogram("server.duration", metric.WithUnit("ms"))).
+		Record(context.TODO(), 50)
+	must(http.Int64Histogram("request_size")).Record(context.TODO(), 150)
+	must(http.Float64Histogram("retries")).Record(context.TODO(), 1.5)
+	must(other.Float64Histogram("response_size")).Record(context.TODO(), 50)
+	must(other.Float64Histogram("unmatched")).Record(context.TODO(), 0.5)
+	// recreating a histogram does not add a match
+	must(other.Float64Histogram("unmatched")).Record(context.TODO(), 0.5)
+
+	snap := scope.Snapshot().Histograms()
+	hsnap, ok := snap["scope.net.http.server.server.duration+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, hsnap.Durations()[100*time.Millisecond])
+
+	hsnap, ok = snap["scope.net.http.server.request_size+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, hsnap.Values()[200.0], "rule order respected")
+
+	hsnap, ok = snap["scope.net.http.server.retries+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, hsnap.Values()[2.0])
+
+	hsnap, ok = snap["scope.other.response_size+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, hsnap.Values()[100.0])
+
+	hsnap, ok = snap["scope.other.unmatched+"]
+	require.True(t, ok)
+	require.EqualValues(t, 2, hsnap.Values()[1.0], "fallback used")
+
+	var got []string
+	for _, m := range reg.Matches() {
+		got = append(got, m.Descriptor.MeterName()+" "+m.Descriptor.Name()+
+			" -> "+m.Rule)
+	}
+	require.Equal(t, []string{
+		"net/http/server server.duration -> http latency",
+		"net/http/server request_size -> sizes",
+		"net/http/server retries -> catch-all http",
+		"other response_size -> sizes",
+		"other unmatched -> ",
+	}, got)
+	require.Equal(t, -1, reg.Matches()[4].Index)
+}
+
+func TestBucketRegistryInvalid(t *testing.T) {
+	t.Parallel()
+	_, err := bridge.NewBucketRegistry(nil, bridge.BucketRule{
+		Instrument: bridge.Regexp("("),
+	})
+	require.ErrorIs(t, err, bridge.ErrInvalidBucketRule)
+
+	_, err = bridge.NewBucketRegistry(nil, bridge.BucketRule{
+		Buckets: bridge.ExponentialBuckets(0, 2, 4),
+	})
+	require.ErrorIs(t, err, bridge.ErrInvalidBucketRule)
+}
+
+func TestBucketRegistryDefaultFallback(t *testing.T) {
+	t.Parallel()
+	reg, err := bridge.NewBucketRegistry(nil)
+	require.NoError(t, err)
+
+	desc := bridge.NewDescriptor("h", bridge.HistogramInstrumentKind,
+		bridge.Float64Kind, "", "")
+	require.Equal(t, bridge.DefaultBucketer(desc), reg.Bucketer(desc))
+}
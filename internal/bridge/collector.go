@@ -0,0 +1,266 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	// defaultCollectInterval is the period on which asynchronous instrument
+	// callbacks are invoked. One second matches the reporting interval most
+	// commonly used with Tally root scopes.
+	defaultCollectInterval = 1 * time.Second
+
+	// defaultCollectLead is how far ahead of each Tally report the
+	// asynchronous instrument callbacks are invoked.
+	defaultCollectLead = 100 * time.Millisecond
+
+	// defaultCallbackTimeout bounds the time that any one asynchronous
+	// instrument callback is allowed to run during a collection.
+	defaultCallbackTimeout = 1 * time.Second
+
+	// selfTelemetryScopeName is the name of the sub-scope of a
+	// MeterProvider's scope to which the bridge reports on itself.
+	selfTelemetryScopeName = "tallyotel"
+
+	failureReasonTag     = "reason"
+	failureReasonError   = "error"
+	failureReasonPanic   = "panic"
+	failureReasonTimeout = "timeout"
+	failureReasonSkipped = "skipped"
+)
+
+var (
+	// ErrCallbackPanic is a base error cause reported when an asynchronous
+	// instrument callback panics.
+	ErrCallbackPanic = errors.New("async callback panicked")
+
+	// ErrCallbackTimeout is a base error cause reported when an asynchronous
+	// instrument callback does not complete within its deadline, or is still
+	// running from a previous collection.
+	ErrCallbackTimeout = errors.New("async callback timed out")
+)
+
+type (
+	// callback is one registered asynchronous callback along with the
+	// self-telemetry instruments that track its invocations.
+	callback struct {
+		name     string
+		run      func(context.Context) error
+		running  int32
+		stats    tally.Scope
+		duration tally.Timer
+	}
+
+	// collector owns the set of asynchronous instrument callbacks registered
+	// by the Meters of a MeterProvider and runs them on a schedule.
+	collector struct {
+		interval time.Duration
+		lead     time.Duration
+		timeout  time.Duration
+		stats    tally.Scope
+
+		mu        sync.Mutex
+		callbacks []*callback
+		flushers  []func()
+
+		loopMu sync.Mutex
+		stop   chan struct{}
+		done   chan struct{}
+	}
+)
+
+func newCollector(
+	interval, lead, timeout time.Duration,
+	stats tally.Scope,
+) *collector {
+	if interval <= 0 {
+		interval = defaultCollectInterval
+	}
+	if lead < 0 || lead > interval {
+		lead = 0
+	}
+	if timeout <= 0 {
+		timeout = defaultCallbackTimeout
+	}
+	return &collector{
+		interval: interval,
+		lead:     lead,
+		timeout:  timeout,
+		stats:    stats.SubScope("callback"),
+	}
+}
+
+// register adds a callback to the set of callbacks that will be run on each
+// collection. The meter and callback names are used to tag the self-telemetry
+// emitted for the callback.
+func (c *collector) register(
+	meter, name string,
+	run func(context.Context) error,
+) *callback {
+	stats := c.stats.Tagged(map[string]string{
+		"meter":    meter,
+		"callback": name,
+	})
+	cb := &callback{
+		name:     name,
+		run:      run,
+		stats:    stats,
+		duration: stats.Timer("duration"),
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.callbacks = append(c.callbacks, cb)
+	return cb
+}
+
+// unregister removes a callback previously returned by register so that it is
+// not run on subsequent collections.
+func (c *collector) unregister(cb *callback) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, registered := range c.callbacks {
+		if registered == cb {
+			c.callbacks = append(c.callbacks[:i], c.callbacks[i+1:]...)
+			return
+		}
+	}
+}
+
+// onCollect adds a func that will be called at the start of each collection.
+// These are used by synchronous instruments that publish values accumulated
+// over each collection interval.
+func (c *collector) onCollect(flush func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.flushers = append(c.flushers, flush)
+}
+
+// start launches the collection loop if it is not already running. The first
+// collection happens one lead time short of a full interval from now and then
+// every interval thereafter so that, if started alongside a Tally root scope
+// with the same reporting interval, every collection precedes a report by the
+// lead time.
+func (c *collector) start() {
+	c.loopMu.Lock()
+	defer c.loopMu.Unlock()
+	if c.stop != nil {
+		return
+	}
+	c.stop = make(chan struct{})
+	c.done = make(chan struct{})
+	go c.loop(c.stop, c.done)
+}
+
+// halt stops the collection loop, if running, and waits for any in-flight
+// collection to complete.
+func (c *collector) halt() {
+	c.loopMu.Lock()
+	defer c.loopMu.Unlock()
+	if c.stop == nil {
+		return
+	}
+	close(c.stop)
+	<-c.done
+	c.stop, c.done = nil, nil
+}
+
+func (c *collector) loop(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	first := time.NewTimer(c.interval - c.lead)
+	defer first.Stop()
+	select {
+	case <-first.C:
+	case <-stop:
+		return
+	}
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.collect(context.Background())
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// collect runs every registered callback once, writing the observed values
+// into the Tally instruments backing each asynchronous instrument. Callbacks
+// are run concurrently and each is given a context with a deadline of the
+// configured callback timeout. Callbacks that return an error, panic or
+// overrun their deadline are reported via the OTEL error handler and counted
+// in the self-telemetry scope; collect never waits for a callback beyond its
+// deadline. Observations made by a callback after its deadline are discarded.
+// Any funcs added with onCollect are called before the callbacks are run.
+func (c *collector) collect(ctx context.Context) {
+	c.mu.Lock()
+	callbacks := append([]*callback(nil), c.callbacks...)
+	flushers := append(([]func())(nil), c.flushers...)
+	c.mu.Unlock()
+
+	for _, flush := range flushers {
+		flush()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	done := make([]chan struct{}, len(callbacks))
+	for i, cb := range callbacks {
+		if !atomic.CompareAndSwapInt32(&cb.running, 0, 1) {
+			cb.fail(failureReasonSkipped, fmt.Errorf(
+				"%w: %s still running from a previous collection",
+				ErrCallbackTimeout, cb.name))
+			continue
+		}
+		done[i] = make(chan struct{})
+		go cb.invoke(ctx, done[i])
+	}
+	for i, cb := range callbacks {
+		if done[i] == nil {
+			continue
+		}
+		select {
+		case <-done[i]:
+			continue
+		case <-ctx.Done():
+		}
+		select {
+		case <-done[i]:
+		default:
+			cb.fail(failureReasonTimeout, fmt.Errorf("%w: %s: %v",
+				ErrCallbackTimeout, cb.name, ctx.Err()))
+		}
+	}
+}
+
+func (cb *callback) invoke(ctx context.Context, done chan<- struct{}) {
+	start := time.Now()
+	defer func() {
+		cb.duration.Record(time.Since(start))
+		if r := recover(); r != nil {
+			cb.fail(failureReasonPanic,
+				fmt.Errorf("%w: %s: %v", ErrCallbackPanic, cb.name, r))
+		}
+		atomic.StoreInt32(&cb.running, 0)
+		close(done)
+	}()
+	if err := cb.run(ctx); err != nil {
+		cb.fail(failureReasonError, fmt.Errorf("%s: %w", cb.name, err))
+	}
+}
+
+func (cb *callback) fail(reason string, err error) {
+	cb.stats.Tagged(map[string]string{failureReasonTag: reason}).
+		Counter("failures").Inc(1)
+	otel.Handle(err)
+}
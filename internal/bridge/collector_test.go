@@ -0,0 +1,129 @@
+package bridge_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func failuresKey(callback, reason string) string {
+	return tally.KeyForPrefixedStringMap(
+		"base.tallyotel.callback.failures",
+		map[string]string{
+			"meter":    "meter",
+			"callback": callback,
+			"reason":   reason,
+		})
+}
+
+func TestCallbackPanic(t *testing.T) {
+	// not parallel - uses global OTEL error handler
+	scope := tally.NewTestScope("base", nil)
+	mp := bridge.NewMeterProvider(scope)
+	m := mp.Meter("meter")
+
+	must(m.Int64ObservableGauge("bad", metric.WithInt64Callback(
+		func(context.Context, metric.Int64Observer) error {
+			panic("oops")
+		})))
+	must(m.Int64ObservableGauge("good", metric.WithInt64Callback(
+		func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(1)
+			return nil
+		})))
+
+	var errs []error
+	withOTELErrorHandler(captureInto(&errs), func() {
+		mp.Collect(context.TODO())
+	})
+
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], bridge.ErrCallbackPanic)
+
+	snap := scope.Snapshot()
+	_, ok := snap.Gauges()["base.meter.good+"]
+	require.True(t, ok, "other callbacks unaffected by panic")
+
+	csnap, ok := snap.Counters()[failuresKey("bad", "panic")]
+	require.True(t, ok)
+	require.EqualValues(t, 1, csnap.Value())
+
+	_, ok = snap.Timers()[tally.KeyForPrefixedStringMap(
+		"base.tallyotel.callback.duration",
+		map[string]string{"meter": "meter", "callback": "good"})]
+	require.True(t, ok)
+}
+
+func TestCallbackTimeout(t *testing.T) {
+	// not parallel - uses global OTEL error handler
+	scope := tally.NewTestScope("base", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithCallbackTimeout(10*time.Millisecond))
+	m := mp.Meter("meter")
+
+	release := make(chan struct{})
+	defer close(release)
+	must(m.Int64ObservableGauge("slow", metric.WithInt64Callback(
+		func(_ context.Context, o metric.Int64Observer) error {
+			<-release
+			o.Observe(1)
+			return nil
+		})))
+	must(m.Int64ObservableGauge("fast", metric.WithInt64Callback(
+		func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(1)
+			return nil
+		})))
+
+	var errs []error
+	withOTELErrorHandler(captureInto(&errs), func() {
+		mp.Collect(context.TODO())
+		mp.Collect(context.TODO())
+	})
+
+	require.Len(t, errs, 2)
+	require.ErrorIs(t, errs[0], bridge.ErrCallbackTimeout)
+	require.ErrorIs(t, errs[1], bridge.ErrCallbackTimeout)
+
+	snap := scope.Snapshot()
+	_, ok := snap.Gauges()["base.meter.fast+"]
+	require.True(t, ok, "other callbacks unaffected by timeout")
+
+	csnap, ok := snap.Counters()[failuresKey("slow", "timeout")]
+	require.True(t, ok)
+	require.EqualValues(t, 1, csnap.Value())
+
+	csnap, ok = snap.Counters()[failuresKey("slow", "skipped")]
+	require.True(t, ok, "still running during second collection")
+	require.EqualValues(t, 1, csnap.Value())
+}
+
+func TestLateObservationDropped(t *testing.T) {
+	// not parallel - uses global OTEL error handler
+	scope := tally.NewTestScope("base", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithCallbackTimeout(time.Millisecond))
+	m := mp.Meter("meter")
+
+	finished := make(chan struct{})
+	must(m.Int64ObservableGauge("late", metric.WithInt64Callback(
+		func(ctx context.Context, o metric.Int64Observer) error {
+			defer close(finished)
+			<-ctx.Done()
+			o.Observe(1)
+			return nil
+		})))
+
+	withOTELErrorHandler(ignoreHandler, func() {
+		mp.Collect(context.TODO())
+	})
+	<-finished
+
+	_, ok := scope.Snapshot().Gauges()["base.meter.late+"]
+	require.False(t, ok, "observation after deadline should be dropped")
+}
@@ -0,0 +1,157 @@
+package bridge
+
+import (
+	"math"
+	"sync"
+
+	tally "github.com/uber-go/tally/v4"
+)
+
+type (
+	// CompanionSuffixes gives the suffixes appended to a histogram's name to
+	// name the companion series emitted alongside it. A companion series with
+	// an empty suffix is not emitted.
+	CompanionSuffixes struct {
+		// Sum names a counter of the sum of recorded values.
+		Sum string
+
+		// Count names a counter of the number of recorded values.
+		Count string
+
+		// Min names a gauge of the least value recorded in each collection
+		// interval.
+		Min string
+
+		// Max names a gauge of the greatest value recorded in each collection
+		// interval.
+		Max string
+	}
+
+	// histogramCompanions creates the companion series of one histogram and
+	// publishes their least and greatest values on each flush.
+	histogramCompanions struct {
+		sum, count, min, max string
+		scale                float64
+
+		mu     sync.Mutex
+		series []*companionSeries
+	}
+
+	// companionSeries holds the companion series of one attribute set of a
+	// histogram.
+	companionSeries struct {
+		sum, count tally.Counter
+		min, max   tally.Gauge
+		scale      float64
+
+		mu        sync.Mutex
+		remainder float64
+		lo, hi    float64
+		seen      bool
+	}
+)
+
+// DefaultCompanionSuffixes gives the suffixes "_sum", "_count", "_min" and
+// "_max". Note that some reporters, such as Prometheus, emit their own sum
+// and count series for histograms under the same suffixes.
+func DefaultCompanionSuffixes() CompanionSuffixes {
+	return CompanionSuffixes{
+		Sum:   "_sum",
+		Count: "_count",
+		Min:   "_min",
+		Max:   "_max",
+	}
+}
+
+func newHistogramCompanions(
+	desc Descriptor,
+	suffixes CompanionSuffixes,
+	scale float64,
+) *histogramCompanions {
+	name := func(suffix string) string {
+		if suffix == "" {
+			return ""
+		}
+		return desc.Name() + suffix
+	}
+	return &histogramCompanions{
+		sum:   name(suffixes.Sum),
+		count: name(suffixes.Count),
+		min:   name(suffixes.Min),
+		max:   name(suffixes.Max),
+		scale: scale,
+	}
+}
+
+// newSeries creates the companion series in the provided scope and retains
+// them to be flushed.
+func (c *histogramCompanions) newSeries(scope tally.Scope) *companionSeries {
+	s := &companionSeries{scale: c.scale}
+	if c.sum != "" {
+		s.sum = scope.Counter(c.sum)
+	}
+	if c.count != "" {
+		s.count = scope.Counter(c.count)
+	}
+	if c.min != "" {
+		s.min = scope.Gauge(c.min)
+	}
+	if c.max != "" {
+		s.max = scope.Gauge(c.max)
+	}
+	c.mu.Lock()
+	c.series = append(c.series, s)
+	c.mu.Unlock()
+	return s
+}
+
+// record updates the companion series with a value recorded to the
+// histogram. The sum is scaled and accumulated as for a FloatCounter.
+func (s *companionSeries) record(value float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.count != nil {
+		s.count.Inc(1)
+	}
+	if s.sum != nil {
+		total := value*s.scale + s.remainder
+		whole := math.Trunc(total)
+		s.remainder = total - whole
+		if whole != 0 {
+			s.sum.Inc(int64(whole))
+		}
+	}
+	if !s.seen {
+		s.lo, s.hi, s.seen = value, value, true
+		return
+	}
+	s.lo = math.Min(s.lo, value)
+	s.hi = math.Max(s.hi, value)
+}
+
+// flush publishes the least and greatest values recorded to each series since
+// the last flush and then resets them. Series with no values recorded since
+// the last flush are not updated.
+func (c *histogramCompanions) flush() {
+	c.mu.Lock()
+	series := c.series
+	c.mu.Unlock()
+	for _, s := range series {
+		s.flush()
+	}
+}
+
+func (s *companionSeries) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.seen {
+		return
+	}
+	if s.min != nil {
+		s.min.Update(s.lo)
+	}
+	if s.max != nil {
+		s.max.Update(s.hi)
+	}
+	s.seen = false
+}
@@ -0,0 +1,98 @@
+package bridge_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestHistogramCompanions(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithSelfTelemetryScope(tally.NoopScope),
+		bridge.WithHistogramBucketer(buckets),
+		bridge.WithHistogramCompanions(bridge.DefaultCompanionSuffixes()))
+	hist := must(mp.Meter("m").Float64Histogram("h"))
+
+	kvs := []attribute.KeyValue{attribute.String("k", "v")}
+	attrs := metric.WithAttributes(kvs...)
+	for _, v := range []float64{2.5, 0.5, 4} {
+		hist.Record(context.TODO(), v, attrs)
+	}
+	hist.Record(context.TODO(), 3)
+	mp.Collect(context.TODO())
+
+	snap := scope.Snapshot()
+	require.EqualValues(t, 3, snap.Counters()[key("scope.m.h_count", kvs)].Value())
+	require.EqualValues(t, 7, snap.Counters()[key("scope.m.h_sum", kvs)].Value())
+	require.EqualValues(t, 0.5, snap.Gauges()[key("scope.m.h_min", kvs)].Value())
+	require.EqualValues(t, 4, snap.Gauges()[key("scope.m.h_max", kvs)].Value())
+	require.EqualValues(t, 1, snap.Counters()["scope.m.h_count+"].Value())
+	require.EqualValues(t, 3, snap.Counters()["scope.m.h_sum+"].Value())
+	require.EqualValues(t, 3, snap.Gauges()["scope.m.h_max+"].Value())
+
+	hist.Record(context.TODO(), 1, attrs)
+	mp.Collect(context.TODO())
+	snap = scope.Snapshot()
+	require.EqualValues(t, 1, snap.Gauges()[key("scope.m.h_min", kvs)].Value(),
+		"min and max reset each interval")
+	require.EqualValues(t, 1, snap.Gauges()[key("scope.m.h_max", kvs)].Value())
+	require.EqualValues(t, 3, snap.Gauges()["scope.m.h_max+"].Value(),
+		"gauges left untouched in idle intervals")
+}
+
+func TestHistogramCompanionsSharedByName(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithSelfTelemetryScope(tally.NoopScope),
+		bridge.WithHistogramBucketer(buckets),
+		bridge.WithHistogramCompanions(bridge.DefaultCompanionSuffixes()))
+	ints := must(mp.Meter("m").Int64Histogram("h"))
+	floats := must(mp.Meter("m").Float64Histogram("h"))
+
+	ints.Record(context.TODO(), 4)
+	floats.Record(context.TODO(), 0.5)
+	mp.Collect(context.TODO())
+
+	snap := scope.Snapshot()
+	require.EqualValues(t, 2, snap.Counters()["scope.m.h_count+"].Value())
+	require.EqualValues(t, 0.5, snap.Gauges()["scope.m.h_min+"].Value())
+	require.EqualValues(t, 4, snap.Gauges()["scope.m.h_max+"].Value())
+}
+
+func TestHistogramCompanionsCustomSuffixes(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithSelfTelemetryScope(tally.NoopScope),
+		bridge.WithDurationHistogramsAsTimers(),
+		bridge.WithHistogramCompanions(bridge.CompanionSuffixes{
+			Sum: ".total",
+			Max: ".peak",
+		}))
+	m := mp.Meter("m")
+	hist := must(m.Float64Histogram("h"))
+	hist.Record(context.TODO(), 0.25)
+	hist.Record(context.TODO(), 0.5)
+	timer := must(m.Float64Histogram("t", metric.WithUnit("ms")))
+	timer.Record(context.TODO(), 1)
+	mp.Collect(context.TODO())
+
+	snap := scope.Snapshot()
+	require.Len(t, snap.Counters(), 1, "only the sum counter emitted")
+	require.Len(t, snap.Gauges(), 1, "only the max gauge emitted")
+	require.EqualValues(t, 0, snap.Counters()["scope.m.h.total+"].Value(),
+		"fractional sum carried forward")
+	require.EqualValues(t, 0.5, snap.Gauges()["scope.m.h.peak+"].Value())
+
+	hist.Record(context.TODO(), 0.25)
+	require.EqualValues(t, 1,
+		scope.Snapshot().Counters()["scope.m.h.total+"].Value())
+}
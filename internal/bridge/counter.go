@@ -4,13 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"sync"
 
 	tally "github.com/uber-go/tally/v4"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/metric/number"
-	"go.opentelemetry.io/otel/metric/sdkapi"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
 )
 
 // ErrNonMonotonicValue is a base error cause returned when a negative value is
@@ -18,79 +16,57 @@ import (
 var ErrNonMonotonicValue = errors.New("unexpected non-monotonic value")
 
 type (
-	// Counter implements the sdkapi.SyncImpl interface wrapping a tally.Counter
+	// Counter implements the metric.Int64Counter and metric.Int64UpDownCounter
+	// interfaces wrapping a tally.Counter
 	Counter struct {
-		desc      sdkapi.Descriptor
-		baseScope tally.Scope
+		embedded.Int64Counter
+		embedded.Int64UpDownCounter
 
-		initDefault sync.Once
-		defaultCtr  tally.Counter
+		desc   Descriptor
+		series *seriesCache[tally.Counter]
 	}
 )
 
 // NewCounter instantiates a new Counter that uses the provided scope as its
 // base scope.
-func NewCounter(desc sdkapi.Descriptor, scope tally.Scope) *Counter {
-	return &Counter{desc: desc, baseScope: scope}
-}
-
-// Implementation is unused
-func (c *Counter) Implementation() interface{} {
-	return nil
+func NewCounter(desc Descriptor, scope tally.Scope) *Counter {
+	return &Counter{
+		desc: desc,
+		series: newSeriesCache(scope, func(s tally.Scope) tally.Counter {
+			return s.Counter(desc.Name())
+		}),
+	}
 }
 
 // Descriptor observes this Counter's Descriptor object
-func (c *Counter) Descriptor() sdkapi.Descriptor {
+func (c *Counter) Descriptor() Descriptor {
 	return c.desc
 }
 
-// RecordOne increments this counter by the provided value. If this Counter is
+// Enabled always returns true as every value is passed to Tally.
+func (c *Counter) Enabled(context.Context) bool {
+	return true
+}
+
+// Add increments this counter by the provided value. If this Counter is
 // configured to be an UpDownCounter then negative values are allowed.
-func (c *Counter) RecordOne(
+func (c *Counter) Add(
 	ctx context.Context,
-	n number.Number,
-	labels []attribute.KeyValue,
+	value int64,
+	opts ...metric.AddOption,
 ) {
-	value := n.AsInt64()
 	if err := validateInt64(c.desc.InstrumentKind(), value); err != nil {
 		otel.Handle(err)
 		return
 	}
-	if len(labels) == 0 {
-		c.recordValidValueToDefault(value)
-		return
-	}
-	scope := c.baseScope.Tagged(KVsToTags(labels))
-	scope.Counter(c.desc.Name()).Inc(value)
+	c.series.get(metric.NewAddConfig(opts).Attributes()).Inc(value)
 }
 
-func (c *Counter) recordValidValueToDefault(valid int64) {
-	c.initDefault.Do(func() {
-		c.defaultCtr = c.baseScope.Counter(c.desc.Name())
-	})
-	c.defaultCtr.Inc(valid)
-}
-
-// RecordOneInScope is used to record a value when the scope can be provided by
-// the caller. This is only known to be the case during Meter batch recordings.
-func (c *Counter) RecordOneInScope(
-	ctx context.Context,
-	scope tally.Scope,
-	n number.Number,
-) {
-	value := n.AsInt64()
-	if err := validateInt64(c.desc.InstrumentKind(), n.AsInt64()); err != nil {
-		otel.Handle(err)
-		return
-	}
-	if scope == c.baseScope {
-		c.recordValidValueToDefault(value)
-		return
-	}
-	scope.Counter(c.desc.Name()).Inc(value)
+func (c *Counter) configureSeries(cfg seriesConfig) {
+	c.series.seriesConfig = cfg
 }
 
-func validateInt64(kind sdkapi.InstrumentKind, value int64) error {
+func validateInt64(kind InstrumentKind, value int64) error {
 	if kind.Monotonic() && value < 0 {
 		return fmt.Errorf("%w: %v", ErrNonMonotonicValue, value)
 	}
@@ -4,37 +4,35 @@ import (
 	"context"
 	"testing"
 
-	"github.com/mmcshane/tallyotel/internal/bridge"
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
 	"github.com/stretchr/testify/require"
 	tally "github.com/uber-go/tally/v4"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/metric/number"
-	"go.opentelemetry.io/otel/metric/sdkapi"
-	"go.opentelemetry.io/otel/metric/unit"
+	"go.opentelemetry.io/otel/metric"
 )
 
 func testCounter(
 	scope string,
 	ctr string,
-	kind sdkapi.InstrumentKind,
+	kind bridge.InstrumentKind,
 ) (tally.TestScope, *bridge.Counter) {
 	tscope := tally.NewTestScope(scope, nil)
 	bctr := bridge.NewCounter(
-		sdkapi.NewDescriptor(ctr, kind, number.Int64Kind, "", unit.Dimensionless), tscope)
+		bridge.NewDescriptor(ctr, kind, bridge.Int64Kind, "", ""), tscope)
 	return tscope, bctr
 }
 
 func TestIncrOnlyCounter(t *testing.T) {
 	// not parallel - uses global OTEL error handler
-	scope, ctr := testCounter("scope", "ctr", sdkapi.CounterInstrumentKind)
+	scope, ctr := testCounter("scope", "ctr", bridge.CounterInstrumentKind)
 
 	withOTELErrorHandler(panicHandler, func() {
 		require.Panics(t, func() {
-			ctr.RecordOne(context.TODO(), number.NewInt64Number(-1), nil)
+			ctr.Add(context.TODO(), -1)
 		}, "otel counter instruments are monotonic")
 	})
 
-	ctr.RecordOne(context.TODO(), number.NewInt64Number(1), nil)
+	ctr.Add(context.TODO(), 1)
 
 	snap, ok := scope.Snapshot().Counters()["scope.ctr+"]
 	require.True(t, ok)
@@ -43,10 +41,10 @@ func TestIncrOnlyCounter(t *testing.T) {
 
 func TestTaggedRecord(t *testing.T) {
 	t.Parallel()
-	scope, ctr := testCounter("scope", "ctr", sdkapi.CounterInstrumentKind)
+	scope, ctr := testCounter("scope", "ctr", bridge.CounterInstrumentKind)
 
-	ctr.RecordOne(context.TODO(), number.NewInt64Number(1),
-		[]attribute.KeyValue{attribute.Key("foo").Int(1)})
+	ctr.Add(context.TODO(), 1,
+		metric.WithAttributes(attribute.Key("foo").Int(1)))
 
 	_, ok := scope.Snapshot().Counters()["scope.ctr+"]
 	require.False(t, ok, "scope.ctr should not be registered in scope")
@@ -58,10 +56,10 @@ func TestTaggedRecord(t *testing.T) {
 
 func TestUpDownCounter(t *testing.T) {
 	t.Parallel()
-	scope, ctr := testCounter("scope", "ctr", sdkapi.UpDownCounterInstrumentKind)
+	scope, ctr := testCounter("scope", "ctr", bridge.UpDownCounterInstrumentKind)
 
-	ctr.RecordOne(context.TODO(), number.NewInt64Number(3), nil)
-	ctr.RecordOne(context.TODO(), number.NewInt64Number(-5), nil)
+	ctr.Add(context.TODO(), 3)
+	ctr.Add(context.TODO(), -5)
 
 	snap, ok := scope.Snapshot().Counters()["scope.ctr+"]
 	require.True(t, ok)
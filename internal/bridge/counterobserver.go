@@ -0,0 +1,107 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type (
+	// CounterObserver bridges an OTEL asynchronous Counter to a tally.Counter.
+	// OTEL counter observers report cumulative values whereas Tally counters
+	// are incremented by deltas so the last value observed for each attribute
+	// set is retained and the counter is incremented by the difference on each
+	// observation. CounterObservers created through a MeterImpl share their
+	// series with any other CounterObserver writing to the same Tally name so
+	// that the last value is kept once per Tally series.
+	CounterObserver[N Number] struct {
+		desc   Descriptor
+		series *seriesCache[*observedCounter]
+	}
+
+	// observedCounter is the state of one attribute set of a
+	// CounterObserver. The last value observed is held as whole units and a
+	// fractional part so that int64 and float64 observations compare
+	// exactly.
+	observedCounter struct {
+		ctr tally.Counter
+
+		mu    sync.Mutex
+		whole int64
+		frac  float64
+		seen  bool
+	}
+)
+
+// NewCounterObserver instantiates a new CounterObserver that uses the provided
+// scope as its base scope.
+func NewCounterObserver[N Number](
+	desc Descriptor,
+	scope tally.Scope,
+) *CounterObserver[N] {
+	return &CounterObserver[N]{
+		desc: desc,
+		series: newSeriesCache(scope,
+			func(s tally.Scope) *observedCounter {
+				return &observedCounter{ctr: s.Counter(desc.Name())}
+			}),
+	}
+}
+
+// Descriptor observes this CounterObserver's Descriptor object
+func (c *CounterObserver[N]) Descriptor() Descriptor {
+	return c.desc
+}
+
+// ObserveOne increments the counter for the provided attributes by the
+// difference between the provided cumulative value and the value last
+// observed for the same attributes. The first observation is treated as a
+// delta from zero. An observation lower than its predecessor is reported as an
+// error satisfying errors.Is(err, ErrNonMonotonicValue) and is then treated as
+// a counter reset, i.e. as a delta from zero.
+func (c *CounterObserver[N]) ObserveOne(
+	ctx context.Context,
+	attrs attribute.Set,
+	value N,
+) {
+	if err := validate(c.desc.InstrumentKind(), value); err != nil {
+		otel.Handle(err)
+		return
+	}
+
+	whole, frac := splitUnits(value)
+	s := c.series.get(attrs)
+	s.mu.Lock()
+	prevWhole, prevFrac, ok := s.whole, s.frac, s.seen
+	s.whole, s.frac, s.seen = whole, frac, true
+	s.mu.Unlock()
+
+	if ok && (whole < prevWhole || whole == prevWhole && frac < prevFrac) {
+		otel.Handle(fmt.Errorf("%w: %v observed after %v",
+			ErrNonMonotonicValue, value, float64(prevWhole)+prevFrac))
+		prevWhole = 0
+	}
+	if delta := whole - prevWhole; delta != 0 {
+		s.ctr.Inc(delta)
+	}
+}
+
+func (c *CounterObserver[N]) configureSeries(cfg seriesConfig) {
+	c.series.seriesConfig = cfg
+}
+
+// splitUnits splits a non-negative number into whole units and a fractional
+// part. Deltas computed between the whole units of cumulative values never
+// lose fractional increments.
+func splitUnits[N Number](value N) (int64, float64) {
+	if f, ok := any(value).(float64); ok {
+		whole := math.Floor(f)
+		return int64(whole), f - whole
+	}
+	return int64(value), 0
+}
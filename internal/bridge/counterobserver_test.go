@@ -0,0 +1,129 @@
+package bridge_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestCounterObserverDeltas(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope)
+	m := mp.Meter("m")
+
+	var total int64
+	must(m.Int64ObservableCounter("bytes", metric.WithInt64Callback(
+		func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(total)
+			o.Observe(10*total, metric.WithAttributes(attribute.Key("dir").String("in")))
+			return nil
+		})))
+
+	total = 3
+	mp.Collect(context.TODO())
+	total = 7
+	mp.Collect(context.TODO())
+	mp.Collect(context.TODO())
+
+	snap := scope.Snapshot().Counters()
+	csnap, ok := snap["scope.m.bytes+"]
+	require.True(t, ok)
+	require.EqualValues(t, 7, csnap.Value())
+
+	csnap, ok = snap["scope.m.bytes+dir=in"]
+	require.True(t, ok)
+	require.EqualValues(t, 70, csnap.Value())
+}
+
+func TestCounterObserverFractional(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope)
+	m := mp.Meter("m")
+
+	var total float64
+	must(m.Float64ObservableCounter("seconds", metric.WithFloat64Callback(
+		func(_ context.Context, o metric.Float64Observer) error {
+			o.Observe(total)
+			return nil
+		})))
+
+	for _, v := range []float64{0.5, 1.25, 1.75, 3.5} {
+		total = v
+		mp.Collect(context.TODO())
+	}
+
+	csnap, ok := scope.Snapshot().Counters()["scope.m.seconds+"]
+	require.True(t, ok)
+	require.EqualValues(t, 3, csnap.Value(), "no fractional increments lost")
+}
+
+func TestCounterObserverReset(t *testing.T) {
+	// not parallel - uses global OTEL error handler
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope)
+	m := mp.Meter("m")
+
+	var total int64
+	must(m.Int64ObservableCounter("ctr", metric.WithInt64Callback(
+		func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(total)
+			return nil
+		})))
+
+	var errs []error
+	withOTELErrorHandler(captureInto(&errs), func() {
+		for _, v := range []int64{10, 4, 6, -1} {
+			total = v
+			mp.Collect(context.TODO())
+		}
+	})
+
+	require.Len(t, errs, 2)
+	require.ErrorIs(t, errs[0], bridge.ErrNonMonotonicValue)
+	require.ErrorIs(t, errs[1], bridge.ErrNonMonotonicValue)
+
+	// 10, then reset to 4 (+4), then 6 (+2), then -1 dropped
+	csnap, ok := scope.Snapshot().Counters()["scope.m.ctr+"]
+	require.True(t, ok)
+	require.EqualValues(t, 16, csnap.Value())
+}
+
+func TestCounterObserverShared(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope)
+	m := mp.Meter("m")
+
+	var ints int64
+	var floats float64
+	must(m.Int64ObservableCounter("x", metric.WithInt64Callback(
+		func(_ context.Context, o metric.Int64Observer) error {
+			if ints > 0 {
+				o.Observe(ints)
+			}
+			return nil
+		})))
+	must(m.Float64ObservableCounter("x", metric.WithFloat64Callback(
+		func(_ context.Context, o metric.Float64Observer) error {
+			if floats > 0 {
+				o.Observe(floats)
+			}
+			return nil
+		})))
+
+	ints = 5
+	mp.Collect(context.TODO())
+	ints, floats = 0, 7.5
+	mp.Collect(context.TODO())
+
+	csnap, ok := scope.Snapshot().Counters()["scope.m.x+"]
+	require.True(t, ok)
+	require.EqualValues(t, 7, csnap.Value(), "one last value per Tally series")
+}
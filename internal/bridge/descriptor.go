@@ -0,0 +1,154 @@
+package bridge
+
+import "fmt"
+
+type (
+	// Number is the set of value types that OTEL instruments record.
+	Number interface {
+		int64 | float64
+	}
+
+	// InstrumentKind identifies the kind of OTEL instrument described by a
+	// Descriptor.
+	InstrumentKind int8
+
+	// NumberKind identifies whether an OTEL instrument records int64 or
+	// float64 values.
+	NumberKind int8
+
+	// Descriptor describes an OTEL instrument. Descriptors are passed to
+	// client-supplied funcs such as HistogramBucketer so that configuration
+	// can vary by instrument.
+	Descriptor struct {
+		meterName      string
+		name           string
+		instrumentKind InstrumentKind
+		numberKind     NumberKind
+		description    string
+		unit           string
+	}
+)
+
+const (
+	// CounterInstrumentKind indicates a Counter instrument.
+	CounterInstrumentKind InstrumentKind = iota
+	// UpDownCounterInstrumentKind indicates an UpDownCounter instrument.
+	UpDownCounterInstrumentKind
+	// HistogramInstrumentKind indicates a Histogram instrument.
+	HistogramInstrumentKind
+	// ObservableCounterInstrumentKind indicates an asynchronous Counter
+	// instrument.
+	ObservableCounterInstrumentKind
+	// ObservableUpDownCounterInstrumentKind indicates an asynchronous
+	// UpDownCounter instrument.
+	ObservableUpDownCounterInstrumentKind
+	// ObservableGaugeInstrumentKind indicates an asynchronous Gauge
+	// instrument.
+	ObservableGaugeInstrumentKind
+	// GaugeInstrumentKind indicates a synchronous Gauge instrument.
+	GaugeInstrumentKind
+)
+
+const (
+	// Int64Kind indicates an instrument recording int64 values.
+	Int64Kind NumberKind = iota
+	// Float64Kind indicates an instrument recording float64 values.
+	Float64Kind
+)
+
+// NewDescriptor instantiates a Descriptor from its constituent parts.
+func NewDescriptor(
+	name string,
+	ikind InstrumentKind,
+	nkind NumberKind,
+	description string,
+	unit string,
+) Descriptor {
+	return Descriptor{
+		name:           name,
+		instrumentKind: ikind,
+		numberKind:     nkind,
+		description:    description,
+		unit:           unit,
+	}
+}
+
+// WithMeterName returns a copy of this Descriptor describing an instrument
+// created by the named Meter.
+func (d Descriptor) WithMeterName(meterName string) Descriptor {
+	d.meterName = meterName
+	return d
+}
+
+// MeterName is the name of the Meter that created the described instrument.
+// It is empty for instruments not created via a MeterProvider.
+func (d Descriptor) MeterName() string {
+	return d.meterName
+}
+
+// Name is the name of the described instrument.
+func (d Descriptor) Name() string {
+	return d.name
+}
+
+// InstrumentKind is the kind of the described instrument.
+func (d Descriptor) InstrumentKind() InstrumentKind {
+	return d.instrumentKind
+}
+
+// NumberKind indicates the type of value recorded by the described instrument.
+func (d Descriptor) NumberKind() NumberKind {
+	return d.numberKind
+}
+
+// Description is the human-readable description of the described instrument.
+func (d Descriptor) Description() string {
+	return d.description
+}
+
+// Unit is the UCUM unit string of the described instrument.
+func (d Descriptor) Unit() string {
+	return d.unit
+}
+
+// Monotonic indicates whether instruments of this kind only accept
+// non-decreasing values.
+func (k InstrumentKind) Monotonic() bool {
+	switch k {
+	case CounterInstrumentKind, ObservableCounterInstrumentKind:
+		return true
+	}
+	return false
+}
+
+// String gives the name of the instrument kind, e.g. "Counter".
+func (k InstrumentKind) String() string {
+	switch k {
+	case CounterInstrumentKind:
+		return "Counter"
+	case UpDownCounterInstrumentKind:
+		return "UpDownCounter"
+	case HistogramInstrumentKind:
+		return "Histogram"
+	case ObservableCounterInstrumentKind:
+		return "ObservableCounter"
+	case ObservableUpDownCounterInstrumentKind:
+		return "ObservableUpDownCounter"
+	case ObservableGaugeInstrumentKind:
+		return "ObservableGauge"
+	case GaugeInstrumentKind:
+		return "Gauge"
+	}
+	return fmt.Sprintf("InstrumentKind(%d)", int8(k))
+}
+
+// String gives the name of the number kind, either "Int64" or "Float64".
+func (k NumberKind) String() string {
+	switch k {
+	case Int64Kind:
+		return "Int64"
+	case Float64Kind:
+		return "Float64"
+	}
+	return fmt.Sprintf("NumberKind(%d)", int8(k))
+}
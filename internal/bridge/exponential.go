@@ -0,0 +1,165 @@
+package bridge
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	tally "github.com/uber-go/tally/v4"
+)
+
+// The range of scales supported by OTEL base-2 exponential histograms.
+const (
+	minExponentialScale = -10
+	maxExponentialScale = 20
+)
+
+// ErrInvalidExponentialBuckets is a base error cause returned when base-2
+// exponential bucket boundaries cannot be derived from the given parameters.
+var ErrInvalidExponentialBuckets = errors.New(
+	"invalid exponential bucket parameters")
+
+// Base2ExponentialBoundaries derives bucket boundaries covering [lower, upper]
+// from the bucket layout of an OTEL base-2 exponential histogram of the
+// provided scale. Each boundary is an integral power of base = 2^(2^-scale)
+// so values fall into buckets that align with those of an OTEL-native
+// exporter. If more than maxBuckets boundaries would be needed then the scale
+// is reduced, halving the resolution each time, until they fit. The scale
+// actually used is returned along with the boundaries.
+func Base2ExponentialBoundaries(
+	scale int,
+	lower, upper float64,
+	maxBuckets int,
+) ([]float64, int, error) {
+	switch {
+	case scale < minExponentialScale || scale > maxExponentialScale:
+		return nil, 0, fmt.Errorf("%w: scale %d outside [%d, %d]",
+			ErrInvalidExponentialBuckets, scale, minExponentialScale,
+			maxExponentialScale)
+	case !(lower > 0) || math.IsInf(upper, 0) || !(upper > lower):
+		return nil, 0, fmt.Errorf("%w: range [%v, %v] must be positive and finite",
+			ErrInvalidExponentialBuckets, lower, upper)
+	case maxBuckets < 2:
+		return nil, 0, fmt.Errorf("%w: at least 2 buckets required, got %d",
+			ErrInvalidExponentialBuckets, maxBuckets)
+	}
+	for ; scale >= minExponentialScale; scale-- {
+		lo, hi := exponentialIndex(lower, scale), exponentialIndex(upper, scale)
+		// boundaries are the lower bound of the bucket holding lower through
+		// to the upper bound of the bucket holding upper
+		if n := hi - lo + 2; n <= maxBuckets {
+			bounds := make([]float64, 0, n)
+			for i := lo; i <= hi+1; i++ {
+				bounds = append(bounds, exponentialBoundary(i, scale))
+			}
+			return bounds, scale, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("%w: [%v, %v] needs more than %d buckets",
+		ErrInvalidExponentialBuckets, lower, upper, maxBuckets)
+}
+
+// exponentialIndex gives the index of the OTEL exponential histogram bucket
+// (base^index, base^(index+1)] holding the positive value v.
+func exponentialIndex(v float64, scale int) int {
+	frac, exp := math.Frexp(v)
+	// v = 1.f * 2^k and exact powers of two are the inclusive upper bound of
+	// a bucket
+	k, exact := exp-1, frac == 0.5
+	if scale <= 0 {
+		if exact {
+			k--
+		}
+		return k >> -scale
+	}
+	if exact {
+		return k<<scale - 1
+	}
+	return int(math.Ceil(math.Log2(v)*math.Exp2(float64(scale)))) - 1
+}
+
+// exponentialBoundary gives base^index for the base of the provided scale.
+func exponentialBoundary(index, scale int) float64 {
+	if scale <= 0 {
+		return math.Ldexp(1, index<<-scale)
+	}
+	return math.Exp2(float64(index) / math.Exp2(float64(scale)))
+}
+
+// Base2ExponentialBuckets declares buckets derived from an OTEL base-2
+// exponential histogram layout (see Base2ExponentialBoundaries).
+func Base2ExponentialBuckets(
+	scale int,
+	lower, upper float64,
+	maxBuckets int,
+) BucketSpec {
+	return BucketSpec{
+		kind:  base2ExponentialBucketSpec,
+		scale: scale,
+		start: lower,
+		step:  upper,
+		count: maxBuckets,
+	}
+}
+
+// Base2ExponentialBucketer creates a HistogramBucketer giving histograms
+// without a unit of time the boundaries derived by Base2ExponentialBoundaries
+// for the range [lower, upper], taken to be in the unit of each histogram.
+// Histograms with a unit of time are given the buckets chosen by fallback, or
+// by DefaultBucketer if fallback is nil; see
+// Base2ExponentialDurationBucketer for a fallback that handles them.
+func Base2ExponentialBucketer(
+	scale int,
+	lower, upper float64,
+	maxBuckets int,
+	fallback HistogramBucketer,
+) (HistogramBucketer, error) {
+	bounds, _, err := Base2ExponentialBoundaries(scale, lower, upper, maxBuckets)
+	if err != nil {
+		return nil, err
+	}
+	if fallback == nil {
+		fallback = DefaultBucketer
+	}
+	return func(desc Descriptor) tally.Buckets {
+		if _, ok := durationUnits[desc.Unit()]; ok {
+			return fallback(desc)
+		}
+		return bucketsFromBoundaries(desc, bounds)
+	}, nil
+}
+
+// Base2ExponentialDurationBucketer creates a HistogramBucketer giving
+// histograms with a unit of time the boundaries derived by
+// Base2ExponentialBoundaries for the range [lower, upper] converted to the
+// unit of each histogram, so that boundaries are powers of the base in that
+// unit as they would be for an OTEL-native exporter. Other histograms are
+// given the buckets chosen by fallback, or by DefaultBucketer if fallback is
+// nil.
+func Base2ExponentialDurationBucketer(
+	scale int,
+	lower, upper time.Duration,
+	maxBuckets int,
+	fallback HistogramBucketer,
+) (HistogramBucketer, error) {
+	byUnit := make(map[string][]float64, len(durationUnits))
+	for unit, per := range durationUnits {
+		bounds, _, err := Base2ExponentialBoundaries(scale,
+			float64(lower)/float64(per), float64(upper)/float64(per),
+			maxBuckets)
+		if err != nil {
+			return nil, err
+		}
+		byUnit[unit] = bounds
+	}
+	if fallback == nil {
+		fallback = DefaultBucketer
+	}
+	return func(desc Descriptor) tally.Buckets {
+		if bounds, ok := byUnit[desc.Unit()]; ok {
+			return bucketsFromBoundaries(desc, bounds)
+		}
+		return fallback(desc)
+	}, nil
+}
@@ -0,0 +1,117 @@
+package bridge_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestBase2ExponentialBoundaries(t *testing.T) {
+	t.Parallel()
+	bounds, scale, err := bridge.Base2ExponentialBoundaries(0, 1, 16, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, scale)
+	require.Equal(t, []float64{0.5, 1, 2, 4, 8, 16}, bounds,
+		"exact powers of two are upper bounds")
+
+	bounds, scale, err = bridge.Base2ExponentialBoundaries(1, 3, 5, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, scale)
+	require.Len(t, bounds, 3)
+	require.InDelta(t, 2*math.Sqrt2, bounds[0], 1e-9)
+	require.InDelta(t, 4, bounds[1], 1e-9)
+	require.InDelta(t, 4*math.Sqrt2, bounds[2], 1e-9)
+
+	bounds, scale, err = bridge.Base2ExponentialBoundaries(-1, 3, 20, 10)
+	require.NoError(t, err)
+	require.Equal(t, -1, scale)
+	require.Equal(t, []float64{1, 4, 16, 64}, bounds)
+}
+
+func TestBase2ExponentialDownscale(t *testing.T) {
+	t.Parallel()
+	// scale 3 from 1ms to 60s needs 129 boundaries
+	bounds, scale, err := bridge.Base2ExponentialBoundaries(3, 1, 60000, 160)
+	require.NoError(t, err)
+	require.Equal(t, 3, scale)
+	require.Len(t, bounds, 129)
+
+	bounds, scale, err = bridge.Base2ExponentialBoundaries(3, 1, 60000, 40)
+	require.NoError(t, err)
+	require.Equal(t, 1, scale, "halved resolution twice to fit")
+	require.LessOrEqual(t, len(bounds), 40)
+	require.LessOrEqual(t, bounds[0], 1.0)
+	require.GreaterOrEqual(t, bounds[len(bounds)-1], 60000.0)
+}
+
+func TestBase2ExponentialInvalid(t *testing.T) {
+	t.Parallel()
+	for _, tt := range [...]struct {
+		name         string
+		scale        int
+		lower, upper float64
+		maxBuckets   int
+	}{
+		{name: "scale", scale: 21, lower: 1, upper: 2, maxBuckets: 10},
+		{name: "zero lower", lower: 0, upper: 2, maxBuckets: 10},
+		{name: "inverted", lower: 2, upper: 1, maxBuckets: 10},
+		{name: "infinite", lower: 1, upper: math.Inf(1), maxBuckets: 10},
+		{name: "too few", lower: 1, upper: 2, maxBuckets: 1},
+		{name: "cannot fit", lower: 1e-300, upper: 1e300, maxBuckets: 2},
+	} {
+		_, _, err := bridge.Base2ExponentialBoundaries(
+			tt.scale, tt.lower, tt.upper, tt.maxBuckets)
+		require.ErrorIs(t, err, bridge.ErrInvalidExponentialBuckets, tt.name)
+	}
+
+	_, err := bridge.NewBucketRegistry(nil, bridge.BucketRule{
+		Buckets: bridge.Base2ExponentialBuckets(0, 0, 1, 10),
+	})
+	require.ErrorIs(t, err, bridge.ErrInvalidBucketRule)
+}
+
+func TestBase2ExponentialBucketer(t *testing.T) {
+	t.Parallel()
+	values, err := bridge.Base2ExponentialBucketer(0, 1, 1000, 20, nil)
+	require.NoError(t, err)
+	bucketer, err := bridge.Base2ExponentialDurationBucketer(0,
+		time.Millisecond, time.Minute, 20, values)
+	require.NoError(t, err)
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope, bridge.WithHistogramBucketer(bucketer))
+	m := mp.Meter("m")
+
+	must(m.Int64Histogram("latency", metric.WithUnit("ms"))).
+		Record(context.TODO(), 100)
+	must(m.Float64Histogram("duration", metric.WithUnit("s"))).
+		Record(context.TODO(), 0.1)
+	must(m.Int64Histogram("size")).Record(context.TODO(), 100)
+
+	snap := scope.Snapshot().Histograms()
+	hsnap, ok := snap["scope.m.latency+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, hsnap.Durations()[128*time.Millisecond])
+
+	hsnap, ok = snap["scope.m.duration+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, hsnap.Durations()[125*time.Millisecond],
+		"range converted to seconds and aligned to powers of 2 seconds")
+
+	hsnap, ok = snap["scope.m.size+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, hsnap.Values()[128.0])
+
+	onlyValues := values(bridge.NewDescriptor("d",
+		bridge.HistogramInstrumentKind, bridge.Float64Kind, "", "s"))
+	require.Equal(t, bridge.SecondBuckets(), onlyValues,
+		"durations left to the fallback")
+
+	_, err = bridge.Base2ExponentialDurationBucketer(0, 0, time.Second, 20, nil)
+	require.ErrorIs(t, err, bridge.ErrInvalidExponentialBuckets)
+}
@@ -0,0 +1,120 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+)
+
+// ErrNonFiniteValue is a base error cause returned when NaN or an infinite
+// value is added to a floating point counter.
+var ErrNonFiniteValue = errors.New("unexpected non-finite value")
+
+type (
+	// FloatCounter implements the metric.Float64Counter and
+	// metric.Float64UpDownCounter interfaces wrapping an integral
+	// tally.Counter. Recorded values are multiplied by a scale factor and
+	// accumulated per series; only whole units are passed on to Tally and the
+	// fractional remainder is carried forward so that no increments are lost
+	// to truncation. FloatCounters created through a MeterImpl share their
+	// series with any other FloatCounter writing to the same Tally name so
+	// that the remainder is kept once per Tally series.
+	FloatCounter struct {
+		embedded.Float64Counter
+		embedded.Float64UpDownCounter
+
+		desc   Descriptor
+		scale  float64
+		series *seriesCache[*floatSeries]
+	}
+
+	// floatSeries is the state of one attribute set of a FloatCounter.
+	floatSeries struct {
+		ctr tally.Counter
+
+		mu        sync.Mutex
+		remainder float64
+	}
+)
+
+// NewFloatCounter instantiates a new FloatCounter that uses the provided scope
+// as its base scope and multiplies every recorded value by the provided scale.
+func NewFloatCounter(
+	desc Descriptor,
+	scope tally.Scope,
+	scale float64,
+) *FloatCounter {
+	return &FloatCounter{
+		desc:  desc,
+		scale: scale,
+		series: newSeriesCache(scope, func(s tally.Scope) *floatSeries {
+			return &floatSeries{ctr: s.Counter(desc.Name())}
+		}),
+	}
+}
+
+// Descriptor observes this FloatCounter's Descriptor object
+func (c *FloatCounter) Descriptor() Descriptor {
+	return c.desc
+}
+
+// Enabled always returns true as every value is passed to Tally.
+func (c *FloatCounter) Enabled(context.Context) bool {
+	return true
+}
+
+// Add adds the provided value to this counter. If this FloatCounter is
+// configured to be an UpDownCounter then negative values are allowed.
+func (c *FloatCounter) Add(
+	ctx context.Context,
+	value float64,
+	opts ...metric.AddOption,
+) {
+	if err := validateFloat64(c.desc.InstrumentKind(), value); err != nil {
+		otel.Handle(err)
+		return
+	}
+	attrs := metric.NewAddConfig(opts).Attributes()
+	c.series.get(attrs).accumulate(value * c.scale)
+}
+
+func (c *FloatCounter) configureSeries(cfg seriesConfig) {
+	c.series.seriesConfig = cfg
+}
+
+// accumulate adds the scaled value to the remainder held for the series and
+// increments its counter by the whole units accumulated so far.
+func (s *floatSeries) accumulate(scaled float64) {
+	s.mu.Lock()
+	acc := s.remainder + scaled
+	whole := math.Trunc(acc)
+	s.remainder = acc - whole
+	s.mu.Unlock()
+	if whole != 0 {
+		s.ctr.Inc(int64(whole))
+	}
+}
+
+func validateFloat64(kind InstrumentKind, value float64) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("%w: %v", ErrNonFiniteValue, value)
+	}
+	if kind.Monotonic() && value < 0 {
+		return fmt.Errorf("%w: %v", ErrNonMonotonicValue, value)
+	}
+	return nil
+}
+
+func validate[N Number](kind InstrumentKind, value N) error {
+	if f, ok := any(value).(float64); ok {
+		return validateFloat64(kind, f)
+	}
+	return validateInt64(kind, int64(value))
+}
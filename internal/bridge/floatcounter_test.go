@@ -0,0 +1,127 @@
+package bridge_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func testFloatCounter(
+	kind bridge.InstrumentKind,
+	scale float64,
+) (tally.TestScope, *bridge.FloatCounter) {
+	tscope := tally.NewTestScope("scope", nil)
+	bctr := bridge.NewFloatCounter(
+		bridge.NewDescriptor("ctr", kind, bridge.Float64Kind, "", ""),
+		tscope,
+		scale)
+	return tscope, bctr
+}
+
+func TestFloatCounterAccumulation(t *testing.T) {
+	t.Parallel()
+	scope, ctr := testFloatCounter(bridge.CounterInstrumentKind, 1)
+
+	for i := 0; i < 10; i++ {
+		ctr.Add(context.TODO(), 0.25)
+	}
+
+	snap, ok := scope.Snapshot().Counters()["scope.ctr+"]
+	require.True(t, ok)
+	require.EqualValues(t, 2, snap.Value(), "2.5 accumulated, 2 emitted")
+
+	ctr.Add(context.TODO(), 0.5)
+
+	snap = scope.Snapshot().Counters()["scope.ctr+"]
+	require.EqualValues(t, 3, snap.Value(), "carried remainder emitted")
+}
+
+func TestFloatCounterScale(t *testing.T) {
+	t.Parallel()
+	scope, ctr := testFloatCounter(bridge.CounterInstrumentKind, 1000)
+	labels := metric.WithAttributes(attribute.Key("foo").String("bar"))
+
+	ctr.Add(context.TODO(), 0.0015, labels)
+	ctr.Add(context.TODO(), 0.0015, labels)
+
+	snap, ok := scope.Snapshot().Counters()["scope.ctr+foo=bar"]
+	require.True(t, ok)
+	require.EqualValues(t, 3, snap.Value())
+}
+
+func TestFloatUpDownCounter(t *testing.T) {
+	t.Parallel()
+	scope, ctr := testFloatCounter(bridge.UpDownCounterInstrumentKind, 1)
+
+	ctr.Add(context.TODO(), 2.5)
+	ctr.Add(context.TODO(), -3.75)
+
+	snap, ok := scope.Snapshot().Counters()["scope.ctr+"]
+	require.True(t, ok)
+	require.EqualValues(t, 2-3, snap.Value(), "-0.25 carried")
+}
+
+func TestFloatCounterInvalid(t *testing.T) {
+	// not parallel - uses global OTEL error handler
+	scope, ctr := testFloatCounter(bridge.CounterInstrumentKind, 1)
+
+	var errs []error
+	withOTELErrorHandler(captureInto(&errs), func() {
+		ctr.Add(context.TODO(), -1)
+		ctr.Add(context.TODO(), math.NaN())
+		ctr.Add(context.TODO(), 1)
+	})
+
+	require.Len(t, errs, 2)
+	require.ErrorIs(t, errs[0], bridge.ErrNonMonotonicValue)
+	require.ErrorIs(t, errs[1], bridge.ErrNonFiniteValue)
+
+	snap, ok := scope.Snapshot().Counters()["scope.ctr+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, snap.Value())
+}
+
+func TestFloatCounterScaler(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope, bridge.WithCounterScaler(
+		func(desc bridge.Descriptor) float64 {
+			if desc.Unit() == "s" {
+				return 1000
+			}
+			return bridge.DefaultScaler(desc)
+		}))
+	m := mp.Meter("m")
+
+	must(m.Float64Counter("busy", metric.WithUnit("s"))).
+		Add(context.TODO(), 0.0125)
+	must(m.Float64Counter("items")).Add(context.TODO(), 1.5)
+
+	snap := scope.Snapshot().Counters()
+	csnap, ok := snap["scope.m.busy+"]
+	require.True(t, ok)
+	require.EqualValues(t, 12, csnap.Value())
+
+	csnap, ok = snap["scope.m.items+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, csnap.Value())
+}
+
+func TestFloatCounterShared(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	m := bridge.NewMeterProvider(scope).Meter("m")
+
+	must(m.Float64Counter("x")).Add(context.TODO(), 0.5)
+	must(m.Float64UpDownCounter("x")).Add(context.TODO(), 0.5)
+
+	snap, ok := scope.Snapshot().Counters()["scope.m.x+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, snap.Value(), "one remainder per Tally series")
+}
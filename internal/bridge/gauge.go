@@ -0,0 +1,58 @@
+package bridge
+
+import (
+	"context"
+
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+)
+
+type (
+	// Gauge implements the metric.Int64Gauge and metric.Float64Gauge
+	// interfaces (for N of int64 and float64 respectively) wrapping a
+	// tally.Gauge. Each recorded value replaces the last value recorded for
+	// the same set of attributes.
+	Gauge[N Number] struct {
+		embedded.Int64Gauge
+		embedded.Float64Gauge
+
+		desc   Descriptor
+		series *seriesCache[tally.Gauge]
+	}
+)
+
+// NewGauge instantiates a new Gauge that uses the provided scope as its base
+// scope.
+func NewGauge[N Number](desc Descriptor, scope tally.Scope) *Gauge[N] {
+	return &Gauge[N]{
+		desc: desc,
+		series: newSeriesCache(scope, func(s tally.Scope) tally.Gauge {
+			return s.Gauge(desc.Name())
+		}),
+	}
+}
+
+// Descriptor observes this Gauge's Descriptor object
+func (g *Gauge[N]) Descriptor() Descriptor {
+	return g.desc
+}
+
+// Enabled always returns true as every value is passed to Tally.
+func (g *Gauge[N]) Enabled(context.Context) bool {
+	return true
+}
+
+// Record sets this gauge to the provided value.
+func (g *Gauge[N]) Record(
+	ctx context.Context,
+	value N,
+	opts ...metric.RecordOption,
+) {
+	attrs := metric.NewRecordConfig(opts).Attributes()
+	g.series.get(attrs).Update(float64(value))
+}
+
+func (g *Gauge[N]) configureSeries(cfg seriesConfig) {
+	g.series.seriesConfig = cfg
+}
@@ -0,0 +1,49 @@
+package bridge_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestGauge(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	g := bridge.NewGauge[int64](
+		bridge.NewDescriptor("g", bridge.GaugeInstrumentKind,
+			bridge.Int64Kind, "", ""),
+		scope)
+	attrs := []attribute.KeyValue{attribute.Key("foo").String("bar")}
+
+	g.Record(context.TODO(), 3)
+	g.Record(context.TODO(), -2)
+	g.Record(context.TODO(), 7, metric.WithAttributes(attrs...))
+
+	snap := scope.Snapshot().Gauges()
+	gsnap, ok := snap["scope.g+"]
+	require.True(t, ok)
+	require.EqualValues(t, -2, gsnap.Value(), "last value wins")
+
+	gsnap, ok = snap[key("scope.g", attrs)]
+	require.True(t, ok)
+	require.EqualValues(t, 7, gsnap.Value())
+}
+
+func TestFloat64Gauge(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope)
+	m := mp.Meter("m")
+
+	temp := must(m.Float64Gauge("temp"))
+	temp.Record(context.TODO(), 21.5)
+
+	gsnap, ok := scope.Snapshot().Gauges()["scope.m.temp+"]
+	require.True(t, ok)
+	require.EqualValues(t, 21.5, gsnap.Value())
+}
@@ -0,0 +1,94 @@
+package bridge
+
+import (
+	"context"
+	"sync"
+
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+)
+
+type (
+	// GaugeCounter implements the metric.Int64UpDownCounter and
+	// metric.Float64UpDownCounter interfaces (for N of int64 and float64
+	// respectively) wrapping a tally.Gauge. A running total is kept per series
+	// and each recorded value updates the gauge with the new total so that
+	// Tally reports the current level rather than per-interval deltas.
+	// GaugeCounters created through a MeterImpl share their series with any
+	// other GaugeCounter writing to the same Tally name so that int64 and
+	// float64 additions accumulate into one total.
+	GaugeCounter[N Number] struct {
+		embedded.Int64UpDownCounter
+		embedded.Float64UpDownCounter
+
+		desc   Descriptor
+		series *seriesCache[*gaugeSeries]
+	}
+
+	// gaugeSeries is the state of one attribute set of a GaugeCounter. Integer
+	// and floating point additions are totalled separately so that int64
+	// totals do not lose precision.
+	gaugeSeries struct {
+		gauge tally.Gauge
+
+		mu     sync.Mutex
+		ints   int64
+		floats float64
+	}
+)
+
+// NewGaugeCounter instantiates a new GaugeCounter that uses the provided scope
+// as its base scope.
+func NewGaugeCounter[N Number](desc Descriptor, scope tally.Scope) *GaugeCounter[N] {
+	return &GaugeCounter[N]{
+		desc: desc,
+		series: newSeriesCache(scope, func(s tally.Scope) *gaugeSeries {
+			return &gaugeSeries{gauge: s.Gauge(desc.Name())}
+		}),
+	}
+}
+
+// Descriptor observes this GaugeCounter's Descriptor object
+func (g *GaugeCounter[N]) Descriptor() Descriptor {
+	return g.desc
+}
+
+// Enabled always returns true as every value is passed to Tally.
+func (g *GaugeCounter[N]) Enabled(context.Context) bool {
+	return true
+}
+
+// Add adds the provided value to the running total for the provided
+// attributes and updates the gauge with the result.
+func (g *GaugeCounter[N]) Add(
+	ctx context.Context,
+	value N,
+	opts ...metric.AddOption,
+) {
+	if err := validate(g.desc.InstrumentKind(), value); err != nil {
+		otel.Handle(err)
+		return
+	}
+	attrs := metric.NewAddConfig(opts).Attributes()
+	addTotal(g.series.get(attrs), value)
+}
+
+func (g *GaugeCounter[N]) configureSeries(cfg seriesConfig) {
+	g.series.seriesConfig = cfg
+}
+
+// addTotal accumulates the valid value into the series' total and publishes
+// the new total. The gauge is updated while holding the lock so that
+// concurrent updates cannot publish a stale total.
+func addTotal[N Number](s *gaugeSeries, valid N) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f, ok := any(valid).(float64); ok {
+		s.floats += f
+	} else {
+		s.ints += int64(valid)
+	}
+	s.gauge.Update(float64(s.ints) + s.floats)
+}
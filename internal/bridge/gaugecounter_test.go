@@ -0,0 +1,78 @@
+package bridge_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestUpDownCounterAsGauge(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope, bridge.WithUpDownCounterAsGauge())
+	m := mp.Meter("m")
+	labels := metric.WithAttributes(attribute.Key("foo").String("bar"))
+
+	inflight := must(m.Int64UpDownCounter("inflight"))
+	inflight.Add(context.TODO(), 3)
+	inflight.Add(context.TODO(), -5)
+	inflight.Add(context.TODO(), 4, labels)
+
+	balance := must(m.Float64UpDownCounter("balance"))
+	balance.Add(context.TODO(), 1.5)
+	balance.Add(context.TODO(), 0.25)
+
+	// counters are unaffected
+	must(m.Int64Counter("ctr")).Add(context.TODO(), 1)
+
+	snap := scope.Snapshot()
+	for name, want := range map[string]float64{
+		"scope.m.inflight+":        -2,
+		"scope.m.inflight+foo=bar": 4,
+		"scope.m.balance+":         1.75,
+	} {
+		gsnap, ok := snap.Gauges()[name]
+		require.True(t, ok, name)
+		require.EqualValues(t, want, gsnap.Value(), name)
+	}
+	_, ok := snap.Counters()["scope.m.inflight+"]
+	require.False(t, ok)
+	_, ok = snap.Counters()["scope.m.ctr+"]
+	require.True(t, ok)
+}
+
+func TestGaugeCounterTagged(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	g := bridge.NewGaugeCounter[int64](
+		bridge.NewDescriptor("g", bridge.UpDownCounterInstrumentKind,
+			bridge.Int64Kind, "", ""),
+		scope)
+	attrs := []attribute.KeyValue{attribute.Key("foo").String("bar")}
+
+	g.Add(context.TODO(), 2, metric.WithAttributes(attrs...))
+	g.Add(context.TODO(), 3, metric.WithAttributes(attrs...))
+
+	gsnap, ok := scope.Snapshot().Gauges()[key("scope.g", attrs)]
+	require.True(t, ok)
+	require.EqualValues(t, 5, gsnap.Value())
+}
+
+func TestGaugeCounterShared(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope, bridge.WithUpDownCounterAsGauge())
+	m := mp.Meter("m")
+
+	must(m.Int64UpDownCounter("x")).Add(context.TODO(), 5)
+	must(m.Float64UpDownCounter("x")).Add(context.TODO(), 1.5)
+
+	gsnap, ok := scope.Snapshot().Gauges()["scope.m.x+"]
+	require.True(t, ok)
+	require.EqualValues(t, 6.5, gsnap.Value(), "one total per Tally series")
+}
@@ -0,0 +1,51 @@
+package bridge
+
+import (
+	"context"
+
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type (
+	// GaugeObserver bridges an OTEL asynchronous Gauge or UpDownCounter to a
+	// tally.Gauge. Each observed value replaces the last value observed for
+	// the same set of attributes.
+	GaugeObserver[N Number] struct {
+		desc   Descriptor
+		series *seriesCache[tally.Gauge]
+	}
+)
+
+// NewGaugeObserver instantiates a new GaugeObserver that uses the provided
+// scope as its base scope.
+func NewGaugeObserver[N Number](
+	desc Descriptor,
+	scope tally.Scope,
+) *GaugeObserver[N] {
+	return &GaugeObserver[N]{
+		desc: desc,
+		series: newSeriesCache(scope, func(s tally.Scope) tally.Gauge {
+			return s.Gauge(desc.Name())
+		}),
+	}
+}
+
+// Descriptor observes this GaugeObserver's Descriptor object
+func (g *GaugeObserver[N]) Descriptor() Descriptor {
+	return g.desc
+}
+
+// ObserveOne updates the gauge for the provided attributes to the provided
+// value.
+func (g *GaugeObserver[N]) ObserveOne(
+	ctx context.Context,
+	attrs attribute.Set,
+	value N,
+) {
+	g.series.get(attrs).Update(float64(value))
+}
+
+func (g *GaugeObserver[N]) configureSeries(cfg seriesConfig) {
+	g.series.seriesConfig = cfg
+}
@@ -0,0 +1,98 @@
+package bridge_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestGaugeObserver(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope)
+	m := mp.Meter("m")
+
+	var depth int64
+	must(m.Int64ObservableGauge("depth", metric.WithInt64Callback(
+		func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(depth)
+			o.Observe(2*depth, metric.WithAttributes(attribute.Key("queue").String("b")))
+			return nil
+		})))
+
+	depth = 3
+	mp.Collect(context.TODO())
+	depth = 5
+	mp.Collect(context.TODO())
+
+	snap := scope.Snapshot().Gauges()
+	gsnap, ok := snap["scope.m.depth+"]
+	require.True(t, ok)
+	require.EqualValues(t, 5, gsnap.Value())
+
+	gsnap, ok = snap["scope.m.depth+queue=b"]
+	require.True(t, ok)
+	require.EqualValues(t, 10, gsnap.Value())
+}
+
+func TestFloat64GaugeObserver(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope)
+	m := mp.Meter("m")
+
+	must(m.Float64ObservableGauge("ratio", metric.WithFloat64Callback(
+		func(_ context.Context, o metric.Float64Observer) error {
+			o.Observe(0.25)
+			return nil
+		})))
+
+	_, ok := scope.Snapshot().Gauges()["scope.m.ratio+"]
+	require.False(t, ok, "gauge should not be written before collection")
+
+	mp.Collect(context.TODO())
+
+	gsnap, ok := scope.Snapshot().Gauges()["scope.m.ratio+"]
+	require.True(t, ok)
+	require.EqualValues(t, 0.25, gsnap.Value())
+}
+
+func TestUpDownCounterObserver(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope)
+	m := mp.Meter("m")
+
+	conns := []int64{4, 2}
+	must(m.Int64ObservableUpDownCounter("conns", metric.WithInt64Callback(
+		func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(conns[0], metric.WithAttributes(attribute.Key("pool").String("a")))
+			o.Observe(conns[1], metric.WithAttributes(attribute.Key("pool").String("b")))
+			return nil
+		})))
+	must(m.Float64ObservableUpDownCounter("balance", metric.WithFloat64Callback(
+		func(_ context.Context, o metric.Float64Observer) error {
+			o.Observe(-1.5)
+			return nil
+		})))
+
+	mp.Collect(context.TODO())
+	conns[0] = 1
+	mp.Collect(context.TODO())
+
+	snap := scope.Snapshot().Gauges()
+	for name, want := range map[string]float64{
+		"scope.m.conns+pool=a": 1,
+		"scope.m.conns+pool=b": 2,
+		"scope.m.balance+":     -1.5,
+	} {
+		gsnap, ok := snap[name]
+		require.True(t, ok, name)
+		require.EqualValues(t, want, gsnap.Value(), name)
+	}
+}
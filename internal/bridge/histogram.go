@@ -2,101 +2,240 @@ package bridge
 
 import (
 	"context"
-	"sync"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	tally "github.com/uber-go/tally/v4"
-	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/metric/number"
-	"go.opentelemetry.io/otel/metric/sdkapi"
-	"go.opentelemetry.io/otel/metric/unit"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
 )
 
+// ErrInvalidBuckets is a base error cause returned when a histogram is created
+// with buckets that had to be corrected before use.
+var ErrInvalidBuckets = errors.New("invalid histogram buckets")
+
+// UCUM unit strings for time.
+const (
+	unitNanoseconds  = "ns"
+	unitMicroseconds = "us"
+	unitMilliseconds = "ms"
+	unitSeconds      = "s"
+	unitMinutes      = "min"
+	unitHours        = "h"
+	unitDays         = "d"
+)
+
+// durationUnits maps the UCUM time units to the time.Duration of one unit.
+var durationUnits = map[string]time.Duration{
+	unitNanoseconds:  time.Nanosecond,
+	unitMicroseconds: time.Microsecond,
+	unitMilliseconds: time.Millisecond,
+	unitSeconds:      time.Second,
+	unitMinutes:      time.Minute,
+	unitHours:        time.Hour,
+	unitDays:         24 * time.Hour,
+}
+
 type (
-	histRecorder func(tally.Histogram, number.Number, number.Kind)
+	histRecorder func(tally.Histogram, float64)
+
+	// histogramSeries is the state for one attribute set of a Histogram. The
+	// tally.Histogram is nil if values are summarized as quantiles instead
+	// and the companions are nil if the histogram has none.
+	histogramSeries struct {
+		hist       tally.Histogram
+		quantiles  *quantileSeries
+		companions *companionSeries
+	}
+
+	// Histogram implements the metric.Int64Histogram and
+	// metric.Float64Histogram interfaces (for N of int64 and float64
+	// respectively), bridging between an OTEL Histogram and a Tally Histogram.
+	Histogram[N Number] struct {
+		embedded.Int64Histogram
+		embedded.Float64Histogram
 
-	// Histogram is a sdkapi.SyncImpl implementation that bridges between an
-	// OTEL Histogram and a Tally Histogram.
-	Histogram struct {
-		desc      sdkapi.Descriptor
-		baseScope tally.Scope
-		record    histRecorder
-		buckets   tally.Buckets
+		desc    Descriptor
+		record  histRecorder
+		buckets tally.Buckets
+		tracker *histogramTracker
+		series  *seriesCache[histogramSeries]
 
-		initDefault sync.Once
-		defaultHist tally.Histogram
+		companions *histogramCompanions
+		quantiles  *histogramQuantiles
 	}
 )
 
 // NewHistogram instantiates a new Histogram that uses the proved scope and
 // bucket configuration.
-func NewHistogram(
-	desc sdkapi.Descriptor,
+func NewHistogram[N Number](
+	desc Descriptor,
 	scope tally.Scope,
 	buckets tally.Buckets,
-) *Histogram {
+) *Histogram[N] {
 	recorder := recordFloat64
-	if desc.Unit() == unit.Milliseconds {
-		recorder = recordMilliseconds
+	if per, ok := durationUnits[desc.Unit()]; ok {
+		recorder = durationRecorder(per)
 	}
-	return &Histogram{
-		desc:      desc,
-		baseScope: scope,
-		record:    recorder,
-		buckets:   buckets,
+	h := &Histogram[N]{
+		desc:    desc,
+		record:  recorder,
+		buckets: buckets,
 	}
+	h.series = newSeriesCache(scope, h.newSeries)
+	return h
 }
 
-// Implementation is unused
-func (h *Histogram) Implementation() interface{} {
-	return nil
+func (h *Histogram[N]) newSeries(scope tally.Scope) histogramSeries {
+	var s histogramSeries
+	if h.quantiles != nil {
+		s.quantiles = h.quantiles.newSeries(scope)
+	} else {
+		s.hist = scope.Histogram(h.desc.Name(), h.buckets)
+	}
+	if h.companions != nil {
+		s.companions = h.companions.newSeries(scope)
+	}
+	return s
 }
 
 // Descriptor observes this Histogram's Descriptor object
-func (h *Histogram) Descriptor() sdkapi.Descriptor {
+func (h *Histogram[N]) Descriptor() Descriptor {
 	return h.desc
 }
 
-// RecordOne adds a value to this histogram.
-func (h *Histogram) RecordOne(
+// Enabled always returns true as every value is passed to Tally.
+func (h *Histogram[N]) Enabled(context.Context) bool {
+	return true
+}
+
+// Record adds a value to this histogram. If this Histogram summarizes values
+// as quantiles then the value is added to the summary rather than to a Tally
+// histogram.
+func (h *Histogram[N]) Record(
 	ctx context.Context,
-	n number.Number,
-	labels []attribute.KeyValue,
+	value N,
+	opts ...metric.RecordOption,
 ) {
-	if len(labels) == 0 {
-		h.recordToDefault(n)
-		return
+	h.recordToSeries(h.series.get(metric.NewRecordConfig(opts).Attributes()),
+		float64(value))
+}
+
+func (h *Histogram[N]) recordToSeries(s histogramSeries, v float64) {
+	if h.tracker != nil {
+		h.tracker.observe(v)
+	}
+	if s.quantiles != nil {
+		s.quantiles.record(v)
+	} else {
+		h.record(s.hist, v)
+	}
+	if s.companions != nil {
+		s.companions.record(v)
 	}
-	s := h.baseScope.Tagged(KVsToTags(labels))
-	h.record(s.Histogram(h.desc.Name(), h.buckets), n, h.desc.NumberKind())
 }
 
-func (h *Histogram) recordToDefault(n number.Number) {
-	h.initDefault.Do(func() {
-		h.defaultHist = h.baseScope.Histogram(h.desc.Name(), h.buckets)
-	})
-	h.record(h.defaultHist, n, h.desc.NumberKind())
+func (h *Histogram[N]) configureSeries(cfg seriesConfig) {
+	h.series.seriesConfig = cfg
 }
 
-// RecordOneInScope is used to record a value when the scope can be provided by
-// the caller. This is only known to be the case during Meter batch recordings.
-func (h *Histogram) RecordOneInScope(
-	ctx context.Context,
-	scope tally.Scope,
-	n number.Number,
-) {
-	if scope == h.baseScope {
-		h.recordToDefault(n)
-		return
+// bucketsFromBoundaries converts explicit bucket boundary advice into Tally
+// buckets. Boundaries for histograms with a unit of time are converted to
+// tally.DurationBuckets to match the use of Histogram.RecordDuration.
+func bucketsFromBoundaries(desc Descriptor, bounds []float64) tally.Buckets {
+	if per, ok := durationUnits[desc.Unit()]; ok {
+		buckets := make(tally.DurationBuckets, 0, len(bounds))
+		for _, b := range bounds {
+			buckets = append(buckets, time.Duration(b*float64(per)))
+		}
+		return buckets
 	}
-	h.record(scope.Histogram(h.desc.Name(), h.buckets), n, h.desc.NumberKind())
+	return append(tally.ValueBuckets(nil), bounds...)
 }
 
-func recordFloat64(hist tally.Histogram, n number.Number, k number.Kind) {
-	hist.RecordValue(n.CoerceToFloat64(k))
+// validateBuckets checks that the buckets for the described histogram are
+// non-empty, sorted, free of duplicates and of the kind matching the way
+// values are recorded: tally.DurationBuckets for histograms with a unit of
+// time and tally.ValueBuckets otherwise. Buckets failing these checks are
+// corrected and returned along with an error satisfying
+// errors.Is(err, ErrInvalidBuckets) that describes the corrections.
+func validateBuckets(
+	desc Descriptor,
+	buckets tally.Buckets,
+) (tally.Buckets, error) {
+	if buckets == nil || buckets.Len() == 0 {
+		return DefaultBucketer(desc), fmt.Errorf(
+			"%w: %s: no buckets given, using defaults", ErrInvalidBuckets,
+			desc.Name())
+	}
+	var (
+		problems []string
+		fixed    tally.Buckets
+	)
+	if per, ok := durationUnits[desc.Unit()]; ok {
+		durations, isDuration := buckets.(tally.DurationBuckets)
+		if !isDuration {
+			problems = append(problems, fmt.Sprintf(
+				"value buckets converted to durations in %s", desc.Unit()))
+			for _, v := range buckets.AsValues() {
+				durations = append(durations,
+					time.Duration(v*float64(per)))
+			}
+		}
+		durations, problems = sortedUnique(durations, problems)
+		fixed = tally.DurationBuckets(durations)
+	} else {
+		values, isValue := buckets.(tally.ValueBuckets)
+		if !isValue {
+			problems = append(problems,
+				"duration buckets converted to values in seconds")
+			values = buckets.AsValues()
+		}
+		values, problems = sortedUnique(values, problems)
+		fixed = tally.ValueBuckets(values)
+	}
+	if len(problems) == 0 {
+		return buckets, nil
+	}
+	return fixed, fmt.Errorf("%w: %s: %s", ErrInvalidBuckets, desc.Name(),
+		strings.Join(problems, ", "))
 }
 
-func recordMilliseconds(hist tally.Histogram, n number.Number, k number.Kind) {
-	dur := time.Duration(n.CoerceToFloat64(k) * float64(time.Millisecond))
-	hist.RecordDuration(dur)
+// sortedUnique gives a sorted copy of bounds with duplicates removed, noting
+// either correction in problems.
+func sortedUnique[T float64 | time.Duration](
+	bounds []T,
+	problems []string,
+) ([]T, []string) {
+	out := append([]T(nil), bounds...)
+	less := func(i, j int) bool { return out[i] < out[j] }
+	if !sort.SliceIsSorted(out, less) {
+		problems = append(problems, "unsorted boundaries sorted")
+		sort.Slice(out, less)
+	}
+	unique := out[:1]
+	for _, b := range out[1:] {
+		if b != unique[len(unique)-1] {
+			unique = append(unique, b)
+		}
+	}
+	if len(unique) < len(out) {
+		problems = append(problems, "duplicate boundaries removed")
+	}
+	return unique, problems
+}
+
+func recordFloat64(hist tally.Histogram, value float64) {
+	hist.RecordValue(value)
+}
+
+// durationRecorder gives a histRecorder that converts values in the unit of
+// time given by per to a time.Duration.
+func durationRecorder(per time.Duration) histRecorder {
+	return func(hist tally.Histogram, value float64) {
+		hist.RecordDuration(time.Duration(value * float64(per)))
+	}
 }
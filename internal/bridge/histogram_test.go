@@ -5,13 +5,10 @@ import (
 	"testing"
 	"time"
 
-	"github.com/mmcshane/tallyotel/internal/bridge"
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
 	"github.com/stretchr/testify/require"
 	tally "github.com/uber-go/tally/v4"
 	"go.opentelemetry.io/otel/metric"
-	"go.opentelemetry.io/otel/metric/number"
-	"go.opentelemetry.io/otel/metric/sdkapi"
-	"go.opentelemetry.io/otel/metric/unit"
 )
 
 /*
@@ -32,8 +29,8 @@ func TestInt64HistToFloat(t *testing.T) {
 	t.Parallel()
 	scope := tally.NewTestScope("scope", nil)
 	mp := bridge.NewMeterProvider(scope)
-	m := metric.Must(mp.Meter("m"))
-	hist := m.NewInt64Histogram("h1")
+	m := mp.Meter("m")
+	hist := must(m.Int64Histogram("h1"))
 
 	hist.Record(context.TODO(), 3)
 
@@ -46,22 +43,22 @@ func TestInt64HistToFloat(t *testing.T) {
 func TestF64Histogram(t *testing.T) {
 	t.Parallel()
 	scope := tally.NewTestScope("scope", nil)
-	hist := bridge.NewHistogram(
-		sdkapi.NewDescriptor(
+	hist := bridge.NewHistogram[float64](
+		bridge.NewDescriptor(
 			"h",
-			sdkapi.HistogramInstrumentKind,
-			number.Float64Kind,
+			bridge.HistogramInstrumentKind,
+			bridge.Float64Kind,
 			"description",
-			unit.Dimensionless),
+			""),
 		scope,
 		tally.MustMakeLinearValueBuckets(0.0, 1.0, 5))
 
-	hist.RecordOne(context.TODO(), number.NewFloat64Number(0.5), nil)
-	hist.RecordOne(context.TODO(), number.NewFloat64Number(1.5), nil)
-	hist.RecordOne(context.TODO(), number.NewFloat64Number(2.5), nil)
-	hist.RecordOne(context.TODO(), number.NewFloat64Number(3.5), nil)
-	hist.RecordOne(context.TODO(), number.NewFloat64Number(3.5), nil)
-	hist.RecordOne(context.TODO(), number.NewFloat64Number(3.5), nil)
+	hist.Record(context.TODO(), 0.5)
+	hist.Record(context.TODO(), 1.5)
+	hist.Record(context.TODO(), 2.5)
+	hist.Record(context.TODO(), 3.5)
+	hist.Record(context.TODO(), 3.5)
+	hist.Record(context.TODO(), 3.5)
 
 	snap, ok := scope.Snapshot().Histograms()["scope.h+"]
 	require.True(t, ok)
@@ -75,20 +72,20 @@ func TestF64Histogram(t *testing.T) {
 func TestInt64Histogram(t *testing.T) {
 	t.Parallel()
 	scope := tally.NewTestScope("scope", nil)
-	hist := bridge.NewHistogram(
-		sdkapi.NewDescriptor(
+	hist := bridge.NewHistogram[int64](
+		bridge.NewDescriptor(
 			"h",
-			sdkapi.HistogramInstrumentKind,
-			number.Int64Kind,
+			bridge.HistogramInstrumentKind,
+			bridge.Int64Kind,
 			"description",
-			unit.Dimensionless),
+			""),
 		scope,
 		tally.MustMakeLinearValueBuckets(0.5, 1.0, 5))
 
-	hist.RecordOne(context.TODO(), number.NewInt64Number(1), nil)
-	hist.RecordOne(context.TODO(), number.NewInt64Number(2), nil)
-	hist.RecordOne(context.TODO(), number.NewInt64Number(3), nil)
-	hist.RecordOne(context.TODO(), number.NewInt64Number(3), nil)
+	hist.Record(context.TODO(), 1)
+	hist.Record(context.TODO(), 2)
+	hist.Record(context.TODO(), 3)
+	hist.Record(context.TODO(), 3)
 
 	snap, ok := scope.Snapshot().Histograms()["scope.h+"]
 	require.True(t, ok)
@@ -102,22 +99,22 @@ func TestInt64Histogram(t *testing.T) {
 func TestDurationHistogram(t *testing.T) {
 	t.Parallel()
 	scope := tally.NewTestScope("scope", nil)
-	hist := bridge.NewHistogram(
-		sdkapi.NewDescriptor(
+	hist := bridge.NewHistogram[float64](
+		bridge.NewDescriptor(
 			"h",
-			sdkapi.HistogramInstrumentKind,
-			number.Float64Kind,
+			bridge.HistogramInstrumentKind,
+			bridge.Float64Kind,
 			"description",
-			unit.Milliseconds),
+			"ms"),
 		scope,
 		tally.MustMakeLinearDurationBuckets(0, 1*time.Second, 5))
 
-	hist.RecordOne(context.TODO(), number.NewFloat64Number(500), nil)
-	hist.RecordOne(context.TODO(), number.NewFloat64Number(1500), nil)
-	hist.RecordOne(context.TODO(), number.NewFloat64Number(2500), nil)
-	hist.RecordOne(context.TODO(), number.NewFloat64Number(3500), nil)
-	hist.RecordOne(context.TODO(), number.NewFloat64Number(3500), nil)
-	hist.RecordOne(context.TODO(), number.NewFloat64Number(3500), nil)
+	hist.Record(context.TODO(), 500)
+	hist.Record(context.TODO(), 1500)
+	hist.Record(context.TODO(), 2500)
+	hist.Record(context.TODO(), 3500)
+	hist.Record(context.TODO(), 3500)
+	hist.Record(context.TODO(), 3500)
 
 	snap, ok := scope.Snapshot().Histograms()["scope.h+"]
 	require.True(t, ok)
@@ -127,3 +124,133 @@ func TestDurationHistogram(t *testing.T) {
 	require.EqualValues(t, 1, vals[3*time.Second])
 	require.EqualValues(t, 3, vals[4*time.Second])
 }
+
+func TestExplicitBucketAdvice(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope, bridge.WithHistogramBucketer(buckets))
+	m := mp.Meter("m")
+
+	must(m.Float64Histogram("advised",
+		metric.WithExplicitBucketBoundaries(10, 20, 40))).
+		Record(context.TODO(), 15)
+	must(m.Int64Histogram("latency", metric.WithUnit("ms"),
+		metric.WithExplicitBucketBoundaries(50, 100))).
+		Record(context.TODO(), 75)
+	must(m.Float64Histogram("fallback")).Record(context.TODO(), 0.5)
+
+	snap := scope.Snapshot().Histograms()
+
+	hsnap, ok := snap["scope.m.advised+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, hsnap.Values()[20.0])
+
+	hsnap, ok = snap["scope.m.latency+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, hsnap.Durations()[100*time.Millisecond])
+
+	hsnap, ok = snap["scope.m.fallback+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, hsnap.Values()[1.0], "HistogramBucketer used")
+}
+
+func TestTimeUnitHistograms(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope)
+	m := mp.Meter("m")
+
+	must(m.Float64Histogram("secs", metric.WithUnit("s"))).
+		Record(context.TODO(), 0.2)
+	must(m.Int64Histogram("micros", metric.WithUnit("us"))).
+		Record(context.TODO(), 40)
+	must(m.Int64Histogram("nanos", metric.WithUnit("ns"),
+		metric.WithExplicitBucketBoundaries(10, 20))).
+		Record(context.TODO(), 15)
+	must(m.Float64Histogram("mins", metric.WithUnit("min"))).
+		Record(context.TODO(), 1.5)
+
+	snap := scope.Snapshot().Histograms()
+	for name, bucket := range map[string]time.Duration{
+		"scope.m.secs+":   250 * time.Millisecond,
+		"scope.m.micros+": 50 * time.Microsecond,
+		"scope.m.nanos+":  20 * time.Nanosecond,
+		"scope.m.mins+":   2 * time.Minute,
+	} {
+		hsnap, ok := snap[name]
+		require.True(t, ok, name)
+		require.EqualValues(t, 1, hsnap.Durations()[bucket], name)
+	}
+}
+
+func TestBucketValidation(t *testing.T) {
+	t.Parallel()
+	for _, tt := range [...]struct {
+		name    string
+		unit    string
+		buckets tally.Buckets
+		want    tally.Buckets
+		valid   bool
+	}{
+		{
+			name:    "valid",
+			buckets: tally.ValueBuckets{1, 2, 3},
+			want:    tally.ValueBuckets{1, 2, 3},
+			valid:   true,
+		},
+		{
+			name:    "unsorted with duplicates",
+			buckets: tally.ValueBuckets{3, 1, 2, 1},
+			want:    tally.ValueBuckets{1, 2, 3},
+		},
+		{
+			name:    "values for duration histogram",
+			unit:    "ms",
+			buckets: tally.ValueBuckets{10, 20},
+			want:    tally.DurationBuckets{10 * time.Millisecond, 20 * time.Millisecond},
+		},
+		{
+			name:    "durations for value histogram",
+			buckets: tally.DurationBuckets{time.Second, 2 * time.Second},
+			want:    tally.ValueBuckets{1, 2},
+		},
+		{
+			name: "empty",
+			unit: "By",
+			want: bridge.ByteBuckets(),
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			scope := tally.NewTestScope("scope", nil)
+			mp := bridge.NewMeterProvider(scope, bridge.WithHistogramBucketer(
+				func(bridge.Descriptor) tally.Buckets {
+					return tt.buckets
+				}))
+
+			hist, err := mp.Meter("m").Float64Histogram("h",
+				metric.WithUnit(tt.unit))
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, bridge.ErrInvalidBuckets)
+			}
+			hist.Record(context.TODO(), 1)
+
+			hsnap, ok := scope.Snapshot().Histograms()["scope.m.h+"]
+			require.True(t, ok)
+			if durations, ok := tt.want.(tally.DurationBuckets); ok {
+				require.Len(t, hsnap.Durations(), len(durations)+1)
+				for _, d := range durations {
+					require.Contains(t, hsnap.Durations(), d)
+				}
+				return
+			}
+			require.Len(t, hsnap.Values(), tt.want.Len()+1)
+			for _, v := range tt.want.AsValues() {
+				require.Contains(t, hsnap.Values(), v)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package bridge
+
+import (
+	"sync"
+
+	tally "github.com/uber-go/tally/v4"
+)
+
+type (
+	// instrumentKey identifies an instrument. Instruments created with equal
+	// keys write to the same Tally series.
+	instrumentKey struct {
+		meter, name, unit string
+		ikind             InstrumentKind
+		nkind             NumberKind
+	}
+
+	// sharedKey identifies state kept per Tally name within a scope, such as
+	// the companion series of a histogram.
+	sharedKey struct {
+		kind  string
+		scope tally.Scope
+		name  string
+	}
+
+	// instruments holds the instruments created by the Meters of a
+	// MeterProvider so that asking for the same instrument again returns the
+	// existing one. Its series cache, and so its cardinality limit, is then
+	// shared by every caller. It also holds state that must be shared
+	// by all instruments writing to the same Tally series.
+	instruments struct {
+		mu    sync.Mutex
+		byKey map[instrumentKey]any
+
+		// sharedMu is separate from mu as shared state is made while
+		// an instrument is being created.
+		sharedMu sync.Mutex
+		shared   map[sharedKey]any
+	}
+)
+
+func newInstruments() *instruments {
+	return &instruments{
+		byKey:  make(map[instrumentKey]any),
+		shared: make(map[sharedKey]any),
+	}
+}
+
+// lookupOrCreate gives the instrument previously created for the described
+// instrument or, if there is none, creates one with create. An error from
+// create is returned only for the call that created the instrument.
+func lookupOrCreate[I any](
+	r *instruments,
+	desc Descriptor,
+	create func() (I, error),
+) (I, error) {
+	key := instrumentKey{
+		meter: desc.MeterName(),
+		name:  desc.Name(),
+		unit:  desc.Unit(),
+		ikind: desc.InstrumentKind(),
+		nkind: desc.NumberKind(),
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if inst, ok := r.byKey[key]; ok {
+		return inst.(I), nil
+	}
+	inst, err := create()
+	r.byKey[key] = inst
+	return inst, err
+}
+
+// shared gives the state of the provided kind kept for the Tally name within
+// scope or, if there is none, creates it with create. Instruments of
+// different number kinds may share a name and so write to the same Tally
+// series; sharing their state keeps it consistent and means that only one
+// flush is registered per series.
+func shared[T any](
+	r *instruments,
+	kind string,
+	scope tally.Scope,
+	name string,
+	create func() *T,
+) *T {
+	key := sharedKey{kind: kind, scope: scope, name: name}
+	r.sharedMu.Lock()
+	defer r.sharedMu.Unlock()
+	if state, ok := r.shared[key]; ok {
+		return state.(*T)
+	}
+	state := create()
+	r.shared[key] = state
+	return state
+}
@@ -0,0 +1,59 @@
+package bridge_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestInstrumentsShared(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithSelfTelemetryScope(tally.NoopScope),
+		bridge.WithCardinalityLimit(2))
+
+	for _, user := range []string{"a", "b", "c", "d", "e"} {
+		ctr := must(mp.Meter("m").Int64Counter("c"))
+		ctr.Add(context.TODO(), 1,
+			metric.WithAttributes(attribute.String("user", user)))
+	}
+	counters := scope.Snapshot().Counters()
+	require.Len(t, counters, 3, "limit shared by every lookup")
+	require.EqualValues(t, 3,
+		counters["scope.m.c+otel_metric_overflow=true"].Value())
+
+	m := mp.Meter("m")
+	require.Same(t, must(m.Float64Histogram("h")),
+		must(mp.Meter("m").Float64Histogram("h")))
+	require.NotSame(t, must(m.Float64Histogram("h")),
+		must(m.Float64Histogram("h", metric.WithUnit("ms"))))
+	require.NotSame(t, must(m.Float64Histogram("h")),
+		must(mp.Meter("other").Float64Histogram("h")))
+}
+
+func TestAsyncInstrumentsShared(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope)
+	cumulative := func(_ context.Context, o metric.Int64Observer) error {
+		o.Observe(100)
+		return nil
+	}
+
+	first := must(mp.Meter("m").Int64ObservableCounter("obs",
+		metric.WithInt64Callback(cumulative)))
+	second := must(mp.Meter("m").Int64ObservableCounter("obs",
+		metric.WithInt64Callback(cumulative)))
+	require.Same(t, first, second)
+
+	mp.Collect(context.TODO())
+	require.EqualValues(t, 100,
+		scope.Snapshot().Counters()["scope.m.obs+"].Value(),
+		"both callbacks observe the one cumulative series")
+}
@@ -6,10 +6,9 @@ import (
 	"fmt"
 
 	tally "github.com/uber-go/tally/v4"
-	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
-	"go.opentelemetry.io/otel/metric/number"
-	"go.opentelemetry.io/otel/metric/sdkapi"
+	"go.opentelemetry.io/otel/metric/embedded"
+	"go.opentelemetry.io/otel/metric/noop"
 )
 
 // ErrUnsupportedInstrument is used to signal that an instrument cannot be
@@ -18,17 +17,26 @@ import (
 var ErrUnsupportedInstrument = errors.New("unsupported instrument")
 
 type (
-	// MeterImpl is an implementation of sdkapi.MeterImpl that uses Tally and
+	// MeterImpl is an implementation of metric.Meter that uses Tally and
 	// wraps a tally.Scope
 	MeterImpl struct {
-		scope   tally.Scope
-		buckets HistogramBucketer
-	}
+		embedded.Meter
+
+		name      string
+		scope     tally.Scope
+		buckets   HistogramBucketer
+		scaler    CounterScaler
+		collector *collector
 
-	syncScopeInstrument interface {
-		// RecordOneInScope provides an optimized method or recording a value
-		// when the scope is known a priori.
-		RecordOneInScope(context.Context, tally.Scope, number.Number)
+		upDownAsGauge bool
+		timers        TimerSelector
+		advisor       *BucketAdvisor
+		companions    *CompanionSuffixes
+		quantiles     QuantileSelector
+		limiter       CardinalityLimiter
+		views         *AttributeViews
+		stats         tally.Scope
+		instruments   *instruments
 	}
 )
 
@@ -36,54 +44,425 @@ type (
 // the provided bucket factory to configure buckets for histograms.
 func NewMeterImpl(scope tally.Scope, buckets HistogramBucketer) *MeterImpl {
 	return &MeterImpl{
-		scope:   scope,
-		buckets: buckets,
+		scope:       scope,
+		buckets:     buckets,
+		scaler:      DefaultScaler,
+		stats:       tally.NoopScope,
+		instruments: newInstruments(),
+		collector: newCollector(defaultCollectInterval, defaultCollectLead,
+			defaultCallbackTimeout, tally.NoopScope),
 	}
 }
 
-// RecordBatch records multiple measurements to multiple instruments in a single
-// call.
-func (m *MeterImpl) RecordBatch(
-	ctx context.Context,
-	labels []attribute.KeyValue,
-	measurements ...metric.Measurement,
-) {
-	scope := m.scope
-	if len(labels) > 0 {
-		scope = scope.Tagged(KVsToTags(labels))
+// Int64Counter creates a Counter wrapping a tally.Counter.
+func (m *MeterImpl) Int64Counter(
+	name string,
+	opts ...metric.Int64CounterOption,
+) (metric.Int64Counter, error) {
+	cfg := metric.NewInt64CounterConfig(opts...)
+	desc := m.descriptor(name, CounterInstrumentKind, Int64Kind,
+		cfg.Description(), cfg.Unit())
+	return lookupOrCreate(m.instruments, desc,
+		func() (metric.Int64Counter, error) {
+			return configured(m, NewCounter(desc, m.scope)), nil
+		})
+}
+
+// Int64UpDownCounter creates a Counter wrapping a tally.Counter or, if this
+// MeterImpl is so configured, a GaugeCounter wrapping a tally.Gauge.
+func (m *MeterImpl) Int64UpDownCounter(
+	name string,
+	opts ...metric.Int64UpDownCounterOption,
+) (metric.Int64UpDownCounter, error) {
+	cfg := metric.NewInt64UpDownCounterConfig(opts...)
+	desc := m.descriptor(name, UpDownCounterInstrumentKind, Int64Kind,
+		cfg.Description(), cfg.Unit())
+	return lookupOrCreate(m.instruments, desc,
+		func() (metric.Int64UpDownCounter, error) {
+			if m.upDownAsGauge {
+				g := NewGaugeCounter[int64](desc, m.scope)
+				g.series = sharedSeries(m, "gauge counter", g, g.series)
+				return g, nil
+			}
+			return configured(m, NewCounter(desc, m.scope)), nil
+		})
+}
+
+// Int64Histogram creates a Histogram wrapping a tally.Histogram, or a Timer
+// wrapping a tally.Timer if the histogram has a unit of time and this
+// MeterImpl's TimerSelector chooses a timer. Histogram buckets are built from
+// explicit bucket boundary advice if given, otherwise they are chosen by this
+// MeterImpl's HistogramBucketer. Buckets that are empty, unsorted, contain
+// duplicates or are of the wrong kind for the histogram's unit are corrected
+// and a usable Histogram is returned along with an error satisfying
+// errors.Is(err, ErrInvalidBuckets). Histograms for which this MeterImpl's
+// QuantileSelector gives quantiles publish them as Tally gauges instead.
+func (m *MeterImpl) Int64Histogram(
+	name string,
+	opts ...metric.Int64HistogramOption,
+) (metric.Int64Histogram, error) {
+	cfg := metric.NewInt64HistogramConfig(opts...)
+	desc := m.descriptor(name, HistogramInstrumentKind, Int64Kind,
+		cfg.Description(), cfg.Unit())
+	return lookupOrCreate(m.instruments, desc,
+		func() (metric.Int64Histogram, error) {
+			if m.useTimer(desc) {
+				return configured(m, NewTimer[int64](desc, m.scope)), nil
+			}
+			return newHistogram[int64](m, desc, cfg.ExplicitBucketBoundaries())
+		})
+}
+
+// Int64Gauge creates a Gauge wrapping a tally.Gauge.
+func (m *MeterImpl) Int64Gauge(
+	name string,
+	opts ...metric.Int64GaugeOption,
+) (metric.Int64Gauge, error) {
+	cfg := metric.NewInt64GaugeConfig(opts...)
+	desc := m.descriptor(name, GaugeInstrumentKind, Int64Kind,
+		cfg.Description(), cfg.Unit())
+	return lookupOrCreate(m.instruments, desc,
+		func() (metric.Int64Gauge, error) {
+			return configured(m, NewGauge[int64](desc, m.scope)), nil
+		})
+}
+
+// Int64ObservableCounter creates an asynchronous counter whose cumulative
+// observations are converted to increments of a tally.Counter.
+func (m *MeterImpl) Int64ObservableCounter(
+	name string,
+	opts ...metric.Int64ObservableCounterOption,
+) (metric.Int64ObservableCounter, error) {
+	cfg := metric.NewInt64ObservableCounterConfig(opts...)
+	desc := m.descriptor(name, ObservableCounterInstrumentKind, Int64Kind,
+		cfg.Description(), cfg.Unit())
+	return m.newInt64Observable(desc, cfg.Callbacks()), nil
+}
+
+// Int64ObservableUpDownCounter creates an asynchronous up-down counter whose
+// observations update a tally.Gauge.
+func (m *MeterImpl) Int64ObservableUpDownCounter(
+	name string,
+	opts ...metric.Int64ObservableUpDownCounterOption,
+) (metric.Int64ObservableUpDownCounter, error) {
+	cfg := metric.NewInt64ObservableUpDownCounterConfig(opts...)
+	desc := m.descriptor(name, ObservableUpDownCounterInstrumentKind,
+		Int64Kind, cfg.Description(), cfg.Unit())
+	return m.newInt64Observable(desc, cfg.Callbacks()), nil
+}
+
+// Int64ObservableGauge creates an asynchronous gauge whose observations update
+// a tally.Gauge.
+func (m *MeterImpl) Int64ObservableGauge(
+	name string,
+	opts ...metric.Int64ObservableGaugeOption,
+) (metric.Int64ObservableGauge, error) {
+	cfg := metric.NewInt64ObservableGaugeConfig(opts...)
+	desc := m.descriptor(name, ObservableGaugeInstrumentKind, Int64Kind,
+		cfg.Description(), cfg.Unit())
+	return m.newInt64Observable(desc, cfg.Callbacks()), nil
+}
+
+// Float64Counter creates a FloatCounter wrapping a tally.Counter. Values are
+// scaled by the factor given by this MeterImpl's CounterScaler.
+func (m *MeterImpl) Float64Counter(
+	name string,
+	opts ...metric.Float64CounterOption,
+) (metric.Float64Counter, error) {
+	cfg := metric.NewFloat64CounterConfig(opts...)
+	desc := m.descriptor(name, CounterInstrumentKind, Float64Kind,
+		cfg.Description(), cfg.Unit())
+	return lookupOrCreate(m.instruments, desc,
+		func() (metric.Float64Counter, error) {
+			ctr := NewFloatCounter(desc, m.scope, m.scaler(desc))
+			ctr.series = sharedSeries(m, "float counter", ctr, ctr.series)
+			return ctr, nil
+		})
+}
+
+// Float64UpDownCounter creates a FloatCounter wrapping a tally.Counter or, if
+// this MeterImpl is so configured, a GaugeCounter wrapping a tally.Gauge.
+func (m *MeterImpl) Float64UpDownCounter(
+	name string,
+	opts ...metric.Float64UpDownCounterOption,
+) (metric.Float64UpDownCounter, error) {
+	cfg := metric.NewFloat64UpDownCounterConfig(opts...)
+	desc := m.descriptor(name, UpDownCounterInstrumentKind, Float64Kind,
+		cfg.Description(), cfg.Unit())
+	return lookupOrCreate(m.instruments, desc,
+		func() (metric.Float64UpDownCounter, error) {
+			if m.upDownAsGauge {
+				g := NewGaugeCounter[float64](desc, m.scope)
+				g.series = sharedSeries(m, "gauge counter", g, g.series)
+				return g, nil
+			}
+			ctr := NewFloatCounter(desc, m.scope, m.scaler(desc))
+			ctr.series = sharedSeries(m, "float counter", ctr, ctr.series)
+			return ctr, nil
+		})
+}
+
+// Float64Histogram creates a Histogram wrapping a tally.Histogram, or a Timer
+// wrapping a tally.Timer if the histogram has a unit of time and this
+// MeterImpl's TimerSelector chooses a timer. Histogram buckets are built from
+// explicit bucket boundary advice if given, otherwise they are chosen by this
+// MeterImpl's HistogramBucketer. Buckets that are empty, unsorted, contain
+// duplicates or are of the wrong kind for the histogram's unit are corrected
+// and a usable Histogram is returned along with an error satisfying
+// errors.Is(err, ErrInvalidBuckets). Histograms for which this MeterImpl's
+// QuantileSelector gives quantiles publish them as Tally gauges instead.
+func (m *MeterImpl) Float64Histogram(
+	name string,
+	opts ...metric.Float64HistogramOption,
+) (metric.Float64Histogram, error) {
+	cfg := metric.NewFloat64HistogramConfig(opts...)
+	desc := m.descriptor(name, HistogramInstrumentKind, Float64Kind,
+		cfg.Description(), cfg.Unit())
+	return lookupOrCreate(m.instruments, desc,
+		func() (metric.Float64Histogram, error) {
+			if m.useTimer(desc) {
+				return configured(m, NewTimer[float64](desc, m.scope)), nil
+			}
+			return newHistogram[float64](m, desc, cfg.ExplicitBucketBoundaries())
+		})
+}
+
+// Float64Gauge creates a Gauge wrapping a tally.Gauge.
+func (m *MeterImpl) Float64Gauge(
+	name string,
+	opts ...metric.Float64GaugeOption,
+) (metric.Float64Gauge, error) {
+	cfg := metric.NewFloat64GaugeConfig(opts...)
+	desc := m.descriptor(name, GaugeInstrumentKind, Float64Kind,
+		cfg.Description(), cfg.Unit())
+	return lookupOrCreate(m.instruments, desc,
+		func() (metric.Float64Gauge, error) {
+			return configured(m, NewGauge[float64](desc, m.scope)), nil
+		})
+}
+
+// Float64ObservableCounter creates an asynchronous counter whose cumulative
+// observations are converted to increments of a tally.Counter. Fractional
+// increments are carried forward between observations.
+func (m *MeterImpl) Float64ObservableCounter(
+	name string,
+	opts ...metric.Float64ObservableCounterOption,
+) (metric.Float64ObservableCounter, error) {
+	cfg := metric.NewFloat64ObservableCounterConfig(opts...)
+	desc := m.descriptor(name, ObservableCounterInstrumentKind, Float64Kind,
+		cfg.Description(), cfg.Unit())
+	return m.newFloat64Observable(desc, cfg.Callbacks()), nil
+}
+
+// Float64ObservableUpDownCounter creates an asynchronous up-down counter whose
+// observations update a tally.Gauge.
+func (m *MeterImpl) Float64ObservableUpDownCounter(
+	name string,
+	opts ...metric.Float64ObservableUpDownCounterOption,
+) (metric.Float64ObservableUpDownCounter, error) {
+	cfg := metric.NewFloat64ObservableUpDownCounterConfig(opts...)
+	desc := m.descriptor(name, ObservableUpDownCounterInstrumentKind,
+		Float64Kind, cfg.Description(), cfg.Unit())
+	return m.newFloat64Observable(desc, cfg.Callbacks()), nil
+}
+
+// Float64ObservableGauge creates an asynchronous gauge whose observations
+// update a tally.Gauge.
+func (m *MeterImpl) Float64ObservableGauge(
+	name string,
+	opts ...metric.Float64ObservableGaugeOption,
+) (metric.Float64ObservableGauge, error) {
+	cfg := metric.NewFloat64ObservableGaugeConfig(opts...)
+	desc := m.descriptor(name, ObservableGaugeInstrumentKind, Float64Kind,
+		cfg.Description(), cfg.Unit())
+	return m.newFloat64Observable(desc, cfg.Callbacks()), nil
+}
+
+// RegisterCallback registers a callback that observes values for one or more
+// of the asynchronous instruments created by this MeterImpl. The callback is
+// invoked periodically by the owning MeterProvider and the values it observes
+// are written to Tally. If any of the instruments was not created by this
+// MeterImpl the error returned here will satisfy
+// errors.Is(err, ErrUnsupportedInstrument).
+func (m *MeterImpl) RegisterCallback(
+	f metric.Callback,
+	instruments ...metric.Observable,
+) (metric.Registration, error) {
+	if len(instruments) == 0 {
+		return noop.Registration{}, nil
 	}
-	for _, m := range measurements {
-		ssi := m.SyncImpl().(syncScopeInstrument)
-		ssi.RecordOneInScope(ctx, scope, m.Number())
+	registered := make(map[metric.Observable]struct{}, len(instruments))
+	var name string
+	for _, inst := range instruments {
+		var (
+			desc  Descriptor
+			meter *MeterImpl
+		)
+		switch o := inst.(type) {
+		case *int64Observable:
+			desc, meter = o.inst.Descriptor(), o.meter
+		case *float64Observable:
+			desc, meter = o.inst.Descriptor(), o.meter
+		default:
+			return nil, fmt.Errorf("%w: %T", ErrUnsupportedInstrument, inst)
+		}
+		if meter != m {
+			return nil, fmt.Errorf("%w: %s was created by a different meter",
+				ErrUnsupportedInstrument, desc.Name())
+		}
+		if name == "" {
+			name = desc.Name()
+		}
+		registered[inst] = struct{}{}
 	}
+	cb := m.collector.register(m.name, name, func(ctx context.Context) error {
+		return f(ctx, &multiObserver{
+			obs:        newObservation(ctx),
+			registered: registered,
+		})
+	})
+	return &registration{collector: m.collector, cb: cb}, nil
 }
 
-// NewSyncInstrument creates new SyncInstrument objects to support OTEL metric
-// instruments. Supported instruments are Counter (int64 only), UpDownCounter
-// (int64 only), Histogram. If a requested instrument is not supported the error
-// returned here will satisfy errors.Is(err, ErrorUnsupportedInstrument).
-func (m *MeterImpl) NewSyncInstrument(
-	desc sdkapi.Descriptor,
-) (sdkapi.SyncImpl, error) {
-	switch desc.InstrumentKind() {
-	case sdkapi.CounterInstrumentKind,
-		sdkapi.UpDownCounterInstrumentKind:
-		if desc.NumberKind() == number.Int64Kind {
-			return NewCounter(desc, m.scope), nil
+func (m *MeterImpl) descriptor(
+	name string,
+	ikind InstrumentKind,
+	nkind NumberKind,
+	description string,
+	unit string,
+) Descriptor {
+	return NewDescriptor(name, ikind, nkind, description, unit).
+		WithMeterName(m.name)
+}
+
+// configured applies this MeterImpl's attribute views and cardinality limit,
+// if it has them, to an instrument. Recordings folded into the
+// instrument's overflow series are counted in the self-telemetry scope.
+func configured[I seriesConfigured](m *MeterImpl, inst I) I {
+	desc := inst.Descriptor()
+	cfg := seriesConfig{view: m.views.match(desc)}
+	if m.limiter != nil {
+		cfg.limit = seriesLimit{
+			max:   m.limiter(desc),
+			meter: m.name,
+			name:  desc.Name(),
+			overflows: m.stats.SubScope("cardinality").Tagged(
+				map[string]string{
+					"meter":      m.name,
+					"instrument": desc.Name(),
+				}).Counter("overflow"),
 		}
-	case sdkapi.HistogramInstrumentKind:
-		return NewHistogram(desc, m.scope, m.buckets(desc)), nil
 	}
-	return nil, fmt.Errorf("%w: %v %v",
-		ErrUnsupportedInstrument, desc.InstrumentKind(), desc.NumberKind())
-}
-
-// NewAsyncInstrument is required by the sdkapi.MeterImpl interface but no
-// asynchronous instruments are supported because Tally doesn't support them.
-func (m *MeterImpl) NewAsyncInstrument(
-	desc sdkapi.Descriptor,
-	runner sdkapi.AsyncRunner,
-) (sdkapi.AsyncImpl, error) {
-	return nil, fmt.Errorf("%w: %v %v",
-		ErrUnsupportedInstrument, desc.InstrumentKind(), desc.NumberKind())
+	inst.configureSeries(cfg)
+	return inst
+}
+
+// sharedSeries gives the series of the first instrument of the provided kind
+// created for the Tally name of inst, configuring inst and returning its own
+// series if it is the first. Instruments of either number kind writing to the
+// same Tally name therefore keep their per-series state, such as running
+// totals and remainders, once per Tally series.
+func sharedSeries[T any, I seriesConfigured](
+	m *MeterImpl,
+	kind string,
+	inst I,
+	series *seriesCache[T],
+) *seriesCache[T] {
+	return shared(m.instruments, kind, m.scope, inst.Descriptor().Name(),
+		func() *seriesCache[T] {
+			configured(m, inst)
+			return series
+		})
+}
+
+func (m *MeterImpl) useTimer(desc Descriptor) bool {
+	_, isDuration := durationUnits[desc.Unit()]
+	return isDuration && m.timers != nil && m.timers(desc)
+}
+
+// newHistogram creates a Histogram with validated buckets, tracked by the
+// MeterImpl's BucketAdvisor if it has one and summarized as quantiles if the
+// MeterImpl's QuantileSelector gives any. Histograms writing to the same
+// Tally name, such as an int64 and a float64 histogram of the same name, share
+// the series of the first created so that each Tally series has one set of
+// companion series and one quantile sketch, flushed once per collection.
+func newHistogram[N Number](
+	m *MeterImpl,
+	desc Descriptor,
+	advice []float64,
+) (*Histogram[N], error) {
+	buckets, err := validateBuckets(desc, m.histogramBuckets(desc, advice))
+	hist := NewHistogram[N](desc, m.scope, buckets)
+	if m.advisor != nil {
+		hist.tracker = m.advisor.track(desc, buckets)
+	}
+	hist.series = shared(m.instruments, "histogram", m.scope, desc.Name(),
+		func() *seriesCache[histogramSeries] {
+			if m.companions != nil {
+				hist.companions = newHistogramCompanions(desc, *m.companions,
+					m.scaler(desc))
+				m.collector.onCollect(hist.companions.flush)
+			}
+			if m.quantiles == nil {
+				return configured(m, hist).series
+			}
+			if qs := m.quantiles(desc); len(qs) > 0 {
+				var qerr error
+				hist.quantiles, qerr = newHistogramQuantiles(desc, qs)
+				m.collector.onCollect(hist.quantiles.flush)
+				err = errors.Join(err, qerr)
+			}
+			return configured(m, hist).series
+		})
+	return hist, err
+}
+
+func (m *MeterImpl) histogramBuckets(
+	desc Descriptor,
+	advice []float64,
+) tally.Buckets {
+	if len(advice) > 0 {
+		return bucketsFromBoundaries(desc, advice)
+	}
+	return m.buckets(desc)
+}
+
+// newInt64Observable gives the int64 observable instrument for the
+// descriptor, creating it if no Meter of the same name has, and registers the
+// provided callbacks with it. Callbacks given each time the instrument is
+// asked for are all registered with the one instrument so that state such as
+// the last value observed by a counter is kept once per Tally series.
+func (m *MeterImpl) newInt64Observable(
+	desc Descriptor,
+	callbacks []metric.Int64Callback,
+) *int64Observable {
+	o, _ := lookupOrCreate(m.instruments, desc,
+		func() (*int64Observable, error) {
+			inst := newAsyncInstrument[int64](m, desc)
+			return &int64Observable{meter: m, inst: inst}, nil
+		})
+	for _, f := range callbacks {
+		m.collector.register(m.name, desc.Name(), func(ctx context.Context) error {
+			return f(ctx, &int64Observer{obs: newObservation(ctx), inst: o.inst})
+		})
+	}
+	return o
+}
+
+// newFloat64Observable is the float64 counterpart of newInt64Observable.
+func (m *MeterImpl) newFloat64Observable(
+	desc Descriptor,
+	callbacks []metric.Float64Callback,
+) *float64Observable {
+	o, _ := lookupOrCreate(m.instruments, desc,
+		func() (*float64Observable, error) {
+			inst := newAsyncInstrument[float64](m, desc)
+			return &float64Observable{meter: m, inst: inst}, nil
+		})
+	for _, f := range callbacks {
+		m.collector.register(m.name, desc.Name(), func(ctx context.Context) error {
+			return f(ctx, &float64Observer{obs: newObservation(ctx), inst: o.inst})
+		})
+	}
+	return o
 }
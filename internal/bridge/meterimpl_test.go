@@ -4,89 +4,37 @@ import (
 	"context"
 	"testing"
 
-	"github.com/mmcshane/tallyotel/internal/bridge"
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
 	"github.com/stretchr/testify/require"
 	tally "github.com/uber-go/tally/v4"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
-	"go.opentelemetry.io/otel/metric/number"
-	"go.opentelemetry.io/otel/metric/sdkapi"
-	"go.opentelemetry.io/otel/metric/unit"
+	"go.opentelemetry.io/otel/metric/noop"
 )
 
 func key(name string, kvs []attribute.KeyValue) string {
 	return tally.KeyForPrefixedStringMap(name, bridge.KVsToTags(kvs))
 }
 
-func buckets(sdkapi.Descriptor) tally.Buckets {
+func buckets(bridge.Descriptor) tally.Buckets {
 	return tally.MustMakeLinearValueBuckets(0.0, 1.0, 5)
 }
 
-func TestBatchRecord(t *testing.T) {
-	for _, tt := range [...]struct {
-		name   string
-		labels []attribute.KeyValue
-	}{
-		{
-			name: "no labels",
-		},
-		{
-			name:   "foo=bar",
-			labels: []attribute.KeyValue{attribute.Key("foo").String("bar")},
-		},
-	} {
-		t.Run(tt.name, func(t *testing.T) {
-			scope := tally.NewTestScope("scope", nil)
-			prov := bridge.NewMeterProvider(
-				scope, bridge.WithHistogramBucketer(buckets))
-			meter := prov.Meter("meter")
-			ctr := metric.Must(meter).NewInt64Counter("a")
-			hist := metric.Must(meter).NewFloat64Histogram("h")
-
-			var batchErrs []error
-			withOTELErrorHandler(captureInto(&batchErrs), func() {
-				meter.RecordBatch(context.TODO(), tt.labels,
-					ctr.Measurement(4),
-					ctr.Measurement(-1), // non-monotonic, should error
-					hist.Measurement(1.5))
-			})
-
-			require.Len(t, batchErrs, 1)
-			require.ErrorIs(t, batchErrs[0], bridge.ErrNonMonotonicValue)
-
-			snap := scope.Snapshot()
-
-			ctrsnap, ok := snap.Counters()[key("scope.meter.a", tt.labels)]
-			require.True(t, ok)
-			require.EqualValues(t, 4, ctrsnap.Value())
-
-			histsnap, ok := snap.Histograms()[key("scope.meter.h", tt.labels)]
-			require.True(t, ok)
-			require.EqualValues(t, 1, histsnap.Values()[2.0])
-		})
-	}
-}
-
 func TestUnsupported(t *testing.T) {
 	t.Parallel()
 	m := bridge.NewMeterImpl(tally.NewTestScope("scope", nil), buckets)
-	_, err := m.NewAsyncInstrument(sdkapi.NewDescriptor(
-		"name",
-		sdkapi.CounterObserverInstrumentKind,
-		number.Int64Kind,
-		"description",
-		unit.Dimensionless,
-	), nil)
-	// none of the async instruments are supported
+
+	foreign := must(noop.NewMeterProvider().Meter("noop").
+		Int64ObservableGauge("g"))
+	_, err := m.RegisterCallback(
+		func(context.Context, metric.Observer) error { return nil },
+		foreign)
 	require.ErrorIs(t, err, bridge.ErrUnsupportedInstrument)
 
-	_, err = m.NewSyncInstrument(sdkapi.NewDescriptor(
-		"name",
-		sdkapi.CounterInstrumentKind,
-		number.Float64Kind, // only integer histograms are supported
-		"description",
-		unit.Dimensionless,
-	))
+	other := bridge.NewMeterImpl(tally.NewTestScope("other", nil), buckets)
+	_, err = m.RegisterCallback(
+		func(context.Context, metric.Observer) error { return nil },
+		must(other.Int64ObservableGauge("g")))
 	require.ErrorIs(t, err, bridge.ErrUnsupportedInstrument)
 }
 
@@ -94,27 +42,15 @@ func TestCounterAliasing(t *testing.T) {
 	t.Parallel()
 	scope := tally.NewTestScope("scope", nil)
 	m := bridge.NewMeterImpl(scope, buckets)
-	i1, err := m.NewSyncInstrument(sdkapi.NewDescriptor(
-		"name",
-		sdkapi.CounterInstrumentKind,
-		number.Int64Kind,
-		"description",
-		unit.Dimensionless,
-	))
+	i1, err := m.Int64Counter("name", metric.WithDescription("description"))
 	require.NoError(t, err)
-	i2, err := m.NewSyncInstrument(sdkapi.NewDescriptor(
-		"name",
-		sdkapi.CounterInstrumentKind,
-		number.Int64Kind,
-		"description",
-		unit.Dimensionless,
-	))
+	i2, err := m.Int64Counter("name", metric.WithDescription("description"))
 	require.NoError(t, err)
 
 	// Distinct instrument instances should write to the same underlying Tally
 	// counter
-	i1.RecordOne(context.TODO(), number.NewInt64Number(1), nil)
-	i2.RecordOne(context.TODO(), number.NewInt64Number(1), nil)
+	i1.Add(context.TODO(), 1)
+	i2.Add(context.TODO(), 1)
 
 	snap := scope.Snapshot()
 
@@ -122,3 +58,79 @@ func TestCounterAliasing(t *testing.T) {
 	require.True(t, ok)
 	require.EqualValues(t, 2, ctrsnap.Value())
 }
+
+func TestMultiInstrumentCallback(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope)
+	meter := mp.Meter("meter")
+
+	gauge := must(meter.Int64ObservableGauge("g"))
+	ctr := must(meter.Float64ObservableCounter("c"))
+	labels := []attribute.KeyValue{attribute.Key("foo").String("bar")}
+
+	var calls int
+	reg, err := meter.RegisterCallback(
+		func(_ context.Context, o metric.Observer) error {
+			calls++
+			o.ObserveInt64(gauge, int64(calls))
+			o.ObserveInt64(gauge, 10, metric.WithAttributes(labels...))
+			o.ObserveFloat64(ctr, 2.5*float64(calls),
+				metric.WithAttributes(labels...))
+			return nil
+		}, gauge, ctr)
+	require.NoError(t, err)
+
+	mp.Collect(context.TODO())
+	mp.Collect(context.TODO())
+	require.Equal(t, 2, calls, "one callback invocation per collection")
+
+	snap := scope.Snapshot()
+
+	gsnap, ok := snap.Gauges()[key("scope.meter.g", nil)]
+	require.True(t, ok)
+	require.EqualValues(t, 2, gsnap.Value())
+
+	gsnap, ok = snap.Gauges()[key("scope.meter.g", labels)]
+	require.True(t, ok)
+	require.EqualValues(t, 10, gsnap.Value())
+
+	csnap, ok := snap.Counters()[key("scope.meter.c", labels)]
+	require.True(t, ok)
+	require.EqualValues(t, 5, csnap.Value())
+
+	require.NoError(t, reg.Unregister())
+	mp.Collect(context.TODO())
+	require.Equal(t, 2, calls, "unregistered callback not invoked")
+}
+
+func TestUnregisteredObservation(t *testing.T) {
+	// not parallel - uses global OTEL error handler
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope)
+	meter := mp.Meter("meter")
+
+	registered := must(meter.Int64ObservableGauge("registered"))
+	unregistered := must(meter.Int64ObservableGauge("unregistered"))
+	_, err := meter.RegisterCallback(
+		func(_ context.Context, o metric.Observer) error {
+			o.ObserveInt64(registered, 1)
+			o.ObserveInt64(unregistered, 1)
+			return nil
+		}, registered)
+	require.NoError(t, err)
+
+	var errs []error
+	withOTELErrorHandler(captureInto(&errs), func() {
+		mp.Collect(context.TODO())
+	})
+
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], bridge.ErrUnregisteredInstrument)
+
+	snap := scope.Snapshot().Gauges()
+	_, ok := snap["scope.meter.registered+"]
+	require.True(t, ok)
+	_, ok = snap["scope.meter.unregistered+"]
+	require.False(t, ok)
+}
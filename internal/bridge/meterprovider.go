@@ -1,13 +1,14 @@
 package bridge
 
 import (
+	"context"
 	"strings"
+	"sync"
 	"time"
 
 	tally "github.com/uber-go/tally/v4"
 	"go.opentelemetry.io/otel/metric"
-	"go.opentelemetry.io/otel/metric/sdkapi"
-	"go.opentelemetry.io/otel/metric/unit"
+	"go.opentelemetry.io/otel/metric/embedded"
 )
 
 var (
@@ -44,10 +45,29 @@ type (
 	// Opt is the type for optional arguments to a MeterProvider.
 	Opt func(*MeterProvider)
 
-	// HistogramBucketer maps metric metadata to a tally.Buckets instance. This
-	// is necessary because OTEL does not have a way to indicate bucket
-	// confguration at histogram creation time.
-	HistogramBucketer func(sdkapi.Descriptor) tally.Buckets
+	// HistogramBucketer maps metric metadata to a tally.Buckets instance. It
+	// is consulted for histograms that were created without explicit bucket
+	// boundary advice (see metric.WithExplicitBucketBoundaries).
+	HistogramBucketer func(Descriptor) tally.Buckets
+
+	// CounterScaler maps metric metadata to a factor by which values recorded
+	// to a floating point counter are multiplied before being accumulated
+	// into an integral tally.Counter.
+	CounterScaler func(Descriptor) float64
+
+	// TimerSelector decides, for a histogram with a unit of time, whether it
+	// should be mapped to a tally.Timer rather than a tally.Histogram.
+	TimerSelector func(Descriptor) bool
+
+	// QuantileSelector gives, for a histogram, the quantiles in [0, 1] to be
+	// published in place of a Tally histogram. A histogram for which no
+	// quantiles are given is mapped to a Tally histogram.
+	QuantileSelector func(Descriptor) []float64
+
+	// CardinalityLimiter gives, for an instrument, the maximum number of
+	// distinct attribute sets for which a Tally series is created.
+	// Zero or less means no limit.
+	CardinalityLimiter func(Descriptor) int
 
 	// MeterScoper is a factory for scopes to be used in a Meter given a meter
 	// name and a base scope.
@@ -56,10 +76,30 @@ type (
 	// MeterProvider is an implementation of metric.Meterprovider wrapping a
 	// tally.Scope.
 	MeterProvider struct {
+		embedded.MeterProvider
+
 		scope       tally.Scope
 		buckets     HistogramBucketer
+		scaler      CounterScaler
 		meterScoper MeterScoper
+		upDownGauge bool
+		timers      TimerSelector
+		advisor     *BucketAdvisor
+		companions  *CompanionSuffixes
+		quantiles   QuantileSelector
+		limiter     CardinalityLimiter
+		views       *AttributeViews
+		sanitizer   *Sanitizer
 		separator   string
+		interval    time.Duration
+		lead        time.Duration
+		timeout     time.Duration
+		stats       tally.Scope
+		collector   *collector
+		instruments *instruments
+
+		mu     sync.Mutex
+		meters map[string]*MeterImpl
 	}
 )
 
@@ -87,11 +127,49 @@ func WithMeterScoper(f MeterScoper) Opt {
 	}
 }
 
-// DefaultBucketer is a HistogramBucketer that gives a hardcoded set of default
-// buckets.
-func DefaultBucketer(desc sdkapi.Descriptor) tally.Buckets {
-	if desc.Unit() == unit.Milliseconds {
-		return append(tally.DurationBuckets(nil), defaultDurationBuckets...)
+// WithCollectInterval sets the period on which a started MeterProvider
+// invokes asynchronous instrument callbacks. This should usually match the
+// reporting interval of the Tally root scope.
+func WithCollectInterval(d time.Duration) Opt {
+	return func(mp *MeterProvider) {
+		mp.interval = d
+	}
+}
+
+// WithCollectLead sets how far ahead of each Tally report a started
+// MeterProvider invokes asynchronous instrument callbacks. Values outside the
+// range [0, interval] are treated as 0.
+func WithCollectLead(d time.Duration) Opt {
+	return func(mp *MeterProvider) {
+		mp.lead = d
+	}
+}
+
+// WithCallbackTimeout sets the deadline given to each asynchronous instrument
+// callback during a collection. Callbacks that overrun are abandoned and
+// reported as errors satisfying errors.Is(err, ErrCallbackTimeout).
+func WithCallbackTimeout(d time.Duration) Opt {
+	return func(mp *MeterProvider) {
+		mp.timeout = d
+	}
+}
+
+// WithSelfTelemetryScope provides the scope to which a MeterProvider reports
+// metrics about its own operation, such as asynchronous callback durations
+// and failures. By default a "tallyotel" sub-scope of the MeterProvider's
+// scope is used. Pass tally.NoopScope to disable self-telemetry.
+func WithSelfTelemetryScope(s tally.Scope) Opt {
+	return func(mp *MeterProvider) {
+		mp.stats = s
+	}
+}
+
+// DefaultBucketer is a HistogramBucketer that gives the built-in buckets for
+// the histogram's unit (see UnitBuckets) or a general purpose set of value
+// buckets if the unit has no built-in bucket set.
+func DefaultBucketer(desc Descriptor) tally.Buckets {
+	if buckets, ok := UnitBuckets(desc.Unit()); ok {
+		return buckets
 	}
 	return append(tally.ValueBuckets(nil), defaultValueBuckets...)
 }
@@ -104,33 +182,227 @@ func WithHistogramBucketer(f HistogramBucketer) Opt {
 	}
 }
 
+// DefaultScaler is a CounterScaler that leaves values unscaled.
+func DefaultScaler(Descriptor) float64 {
+	return 1
+}
+
+// WithCounterScaler wraps a counter scale factory into a MeterProvider option
+func WithCounterScaler(f CounterScaler) Opt {
+	return func(mp *MeterProvider) {
+		mp.scaler = f
+	}
+}
+
+// WithUpDownCounterAsGauge configures a MeterProvider to map synchronous
+// UpDownCounters to Tally gauges that report the running total for each
+// attribute set, rather than to Tally counters that report per-interval
+// deltas.
+func WithUpDownCounterAsGauge() Opt {
+	return func(mp *MeterProvider) {
+		mp.upDownGauge = true
+	}
+}
+
+// WithDurationHistogramsAsTimers configures a MeterProvider to map all
+// histograms with a unit of time to Tally timers rather than Tally histograms.
+func WithDurationHistogramsAsTimers() Opt {
+	return WithTimerSelector(func(Descriptor) bool {
+		return true
+	})
+}
+
+// WithTimerSelector provides a TimerSelector to a MeterProvider at
+// construction time, allowing the choice between a Tally timer and a Tally
+// histogram to be made per instrument. Histograms without a unit of time are
+// always mapped to Tally histograms.
+func WithTimerSelector(f TimerSelector) Opt {
+	return func(mp *MeterProvider) {
+		mp.timers = f
+	}
+}
+
+// WithBucketAdvisor attaches a BucketAdvisor to a MeterProvider so that the
+// values recorded to every histogram (but not to timers) are tracked.
+func WithBucketAdvisor(a *BucketAdvisor) Opt {
+	return func(mp *MeterProvider) {
+		mp.advisor = a
+	}
+}
+
+// WithHistogramCompanions configures a MeterProvider to maintain, alongside
+// every histogram (but not timers), companion series on the same tagged scope:
+// a counter of the sum of recorded values, a counter of the number of recorded
+// values and gauges of the least and greatest values recorded in each
+// collection interval. Companion series are named by appending the provided
+// suffixes to the histogram's name (see DefaultCompanionSuffixes). The sum is
+// scaled by the CounterScaler as for floating point counters. The min and max
+// gauges are published when the MeterProvider collects so it must be started
+// (see Start) for them to be reported.
+func WithHistogramCompanions(suffixes CompanionSuffixes) Opt {
+	return func(mp *MeterProvider) {
+		mp.companions = &suffixes
+	}
+}
+
+// WithHistogramQuantiles configures a MeterProvider to summarize the values
+// recorded to every histogram (but not timers) as the provided quantiles, e.g.
+// 0.5, 0.9 and 0.99, rather than recording them to a Tally histogram.
+func WithHistogramQuantiles(quantiles ...float64) Opt {
+	qs := append([]float64(nil), quantiles...)
+	return WithQuantileSelector(func(Descriptor) []float64 {
+		return qs
+	})
+}
+
+// WithQuantileSelector provides a QuantileSelector to a MeterProvider at
+// construction time, allowing the choice between a quantile summary and a
+// Tally histogram to be made per instrument. For each attribute set of a
+// summarized histogram, values are added to a sketch with bounded memory and
+// 1% relative error. On each collection the selected quantiles are estimated
+// and published as Tally gauges, named as the histogram and tagged with
+// "quantile", and the sketch is reset. The MeterProvider must be started (see
+// Start) for the gauges to be reported. Quantiles outside [0, 1] are ignored
+// and reported as an error satisfying errors.Is(err, ErrInvalidQuantile) when
+// the histogram is created.
+func WithQuantileSelector(f QuantileSelector) Opt {
+	return func(mp *MeterProvider) {
+		mp.quantiles = f
+	}
+}
+
+// WithCardinalityLimit configures a MeterProvider to limit every instrument to
+// n distinct attribute sets (see WithCardinalityLimiter).
+func WithCardinalityLimit(n int) Opt {
+	return WithCardinalityLimiter(func(Descriptor) int {
+		return n
+	})
+}
+
+// WithCardinalityLimiter provides a CardinalityLimiter to a MeterProvider at
+// construction time, allowing the limit on distinct attribute sets to be set
+// per instrument. Tally never releases tagged scopes so an instrument
+// recorded or observed with an unbounded attribute, such as a user ID, would
+// otherwise grow without bound. Once an instrument has series for its limit
+// of attribute sets, values recorded or observed with any other set are
+// folded into a single series tagged otel_metric_overflow=true. The first such value is reported
+// as an error satisfying errors.Is(err, ErrCardinalityLimit) and every such
+// value is counted by the "cardinality.overflow" counter in the self-telemetry
+// scope.
+func WithCardinalityLimiter(f CardinalityLimiter) Opt {
+	return func(mp *MeterProvider) {
+		mp.limiter = f
+	}
+}
+
+// WithAttributeViews provides AttributeViews to a MeterProvider at
+// construction time so that the attributes recorded to, or observed by, each
+// instrument are filtered, mapped and renamed by the first matching
+// AttributeView before being passed to Tally as tags.
+func WithAttributeViews(av *AttributeViews) Opt {
+	return func(mp *MeterProvider) {
+		mp.views = av
+	}
+}
+
+// WithSanitizer configures a MeterProvider to rewrite the sub-scope names
+// derived from Meter names, instrument names, tag keys and tag values with the
+// provided Sanitizer before passing them to Tally. Patterns in bucket rules
+// and attribute views and the funcs given to other options still see the
+// original OTEL names and attributes. The self-telemetry scope is not
+// sanitized.
+func WithSanitizer(s *Sanitizer) Opt {
+	return func(mp *MeterProvider) {
+		mp.sanitizer = s
+	}
+}
+
 // NewMeterProvider creates a new MeterProvider wrapping the provided
 // tally.Scope.
-func NewMeterProvider(scope tally.Scope, opts ...Opt) metric.MeterProvider {
+func NewMeterProvider(scope tally.Scope, opts ...Opt) *MeterProvider {
 	mp := &MeterProvider{
 		scope:       scope,
 		buckets:     DefaultBucketer,
+		scaler:      DefaultScaler,
 		meterScoper: defaultMeterScoper,
 		separator:   tally.DefaultSeparator,
+		interval:    defaultCollectInterval,
+		lead:        defaultCollectLead,
+		timeout:     defaultCallbackTimeout,
+		stats:       scope.SubScope(selfTelemetryScopeName),
 	}
 	for _, opt := range opts {
 		opt(mp)
 	}
+	if mp.sanitizer != nil {
+		mp.scope = mp.sanitizer.wrap(mp.scope, mp.scope)
+	}
+	mp.collector = newCollector(mp.interval, mp.lead, mp.timeout, mp.stats)
+	mp.instruments = newInstruments()
+	mp.meters = make(map[string]*MeterImpl)
 	return mp
 }
 
-// Meter creates a new metric.Meter implementation that wraps a tally.Scope that
-// is a sub-scope of the scope provided to this MeterProvider at construction
-// time.
+// Meter gives the metric.Meter implementation for the provided name, which
+// wraps a tally.Scope that is a sub-scope of the scope provided to this
+// MeterProvider at construction time. Meters are created once per name and
+// asking for a Meter of the same name again, whatever the options, returns
+// the same Meter. Instruments are likewise shared: asking for an instrument
+// of the same name, kind, number kind and unit returns the instrument created
+// first, with any callbacks given for an asynchronous instrument added to it.
 func (p *MeterProvider) Meter(
 	instrumentationName string,
 	opts ...metric.MeterOption,
 ) metric.Meter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if impl, ok := p.meters[instrumentationName]; ok {
+		return impl
+	}
 	trimmed := strings.Trim(instrumentationName, p.separator)
 	parts := strings.Split(trimmed, p.separator)
 	impl := &MeterImpl{
-		scope:   p.meterScoper(parts, p.scope),
-		buckets: p.buckets,
+		name:      instrumentationName,
+		scope:     p.meterScoper(parts, p.scope),
+		buckets:   p.buckets,
+		scaler:    p.scaler,
+		collector: p.collector,
+
+		upDownAsGauge: p.upDownGauge,
+		timers:        p.timers,
+		advisor:       p.advisor,
+		companions:    p.companions,
+		quantiles:     p.quantiles,
+		limiter:       p.limiter,
+		views:         p.views,
+		stats:         p.stats,
+		instruments:   p.instruments,
 	}
-	return metric.WrapMeterImpl(impl)
+	p.meters[instrumentationName] = impl
+	return impl
+}
+
+// Collect synchronously invokes the callbacks of all asynchronous instruments
+// created by Meters of this MeterProvider, writing the observed values to
+// Tally. This is done periodically once Start has been called but Collect can
+// also be called directly, e.g. from tests.
+func (p *MeterProvider) Collect(ctx context.Context) {
+	p.collector.collect(ctx)
+}
+
+// Start begins periodic invocation of asynchronous instrument callbacks on
+// the interval given via WithCollectInterval. If this MeterProvider is started
+// immediately after the Tally root scope is created and the collection
+// interval matches the Tally reporting interval then the callbacks will run
+// shortly (see WithCollectLead) before each report. Calling Start on a
+// started MeterProvider has no effect.
+func (p *MeterProvider) Start() {
+	p.collector.start()
+}
+
+// Stop halts the periodic invocation of asynchronous instrument callbacks,
+// waiting for any in-progress collection to finish. A stopped MeterProvider
+// can be started again.
+func (p *MeterProvider) Stop() {
+	p.collector.halt()
 }
@@ -2,9 +2,11 @@ package bridge_test
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
+	"time"
 
-	"github.com/mmcshane/tallyotel/internal/bridge"
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
 	"github.com/stretchr/testify/require"
 	tally "github.com/uber-go/tally/v4"
 	"go.opentelemetry.io/otel/metric"
@@ -17,8 +19,8 @@ func TestMeterReuseProviderScope(t *testing.T) {
 		func(_ []string, base tally.Scope) tally.Scope {
 			return base
 		}))
-	m := metric.Must(mp.Meter("meter"))
-	m.NewInt64Counter("c").Add(context.TODO(), 1)
+	m := mp.Meter("meter")
+	must(m.Int64Counter("c")).Add(context.TODO(), 1)
 	ctrSnaps := scope.Snapshot().Counters()
 
 	_, ok := ctrSnaps["base.meter.c+"]
@@ -28,6 +30,28 @@ func TestMeterReuseProviderScope(t *testing.T) {
 	require.True(t, ok, "counter name should be base.c")
 }
 
+func TestMeterPerName(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope)
+	require.Same(t, mp.Meter("a"), mp.Meter("a"))
+	require.NotSame(t, mp.Meter("a"), mp.Meter("b"))
+
+	gauge := must(mp.Meter("a").Int64ObservableGauge("g"))
+	_, err := mp.Meter("a").RegisterCallback(
+		func(_ context.Context, o metric.Observer) error {
+			o.ObserveInt64(gauge, 1)
+			return nil
+		}, gauge)
+	require.NoError(t, err, "instrument from a Meter of the same name")
+	mp.Collect(context.TODO())
+	require.EqualValues(t, 1, scope.Snapshot().Gauges()["scope.a.g+"].Value())
+
+	_, err = mp.Meter("b").RegisterCallback(
+		func(context.Context, metric.Observer) error { return nil }, gauge)
+	require.ErrorIs(t, err, bridge.ErrUnsupportedInstrument)
+}
+
 func TestSTandardMeterNamingDoubleScope(t *testing.T) {
 	t.Parallel()
 	scope := tally.NewTestScope("", nil)
@@ -35,8 +59,8 @@ func TestSTandardMeterNamingDoubleScope(t *testing.T) {
 		func(_ []string, base tally.Scope) tally.Scope {
 			return base.SubScope("x").SubScope("y")
 		}))
-	m := metric.Must(mp.Meter("meter"))
-	m.NewInt64Counter("c").Add(context.TODO(), 1)
+	m := mp.Meter("meter")
+	must(m.Int64Counter("c")).Add(context.TODO(), 1)
 	ctrSnaps := scope.Snapshot().Counters()
 
 	_, ok := ctrSnaps["x.y.c+"]
@@ -47,8 +71,8 @@ func TestStandardMeterNaming(t *testing.T) {
 	t.Parallel()
 	scope := tally.NewTestScope("base", nil)
 	mp := bridge.NewMeterProvider(scope)
-	m := metric.Must(mp.Meter("meter"))
-	m.NewInt64Counter("c").Add(context.TODO(), 1)
+	m := mp.Meter("meter")
+	must(m.Int64Counter("c")).Add(context.TODO(), 1)
 	ctrSnaps := scope.Snapshot().Counters()
 
 	_, ok := ctrSnaps["base.meter.c+"]
@@ -59,10 +83,47 @@ func TestMeterNameSplitting(t *testing.T) {
 	t.Parallel()
 	scope := tally.NewTestScope("base", nil)
 	mp := bridge.NewMeterProvider(scope, bridge.WithScopeNameSeparator("@"))
-	m := metric.Must(mp.Meter("@foo@bar@baz@@@"))
-	m.NewInt64Counter("c").Add(context.TODO(), 1)
+	m := mp.Meter("@foo@bar@baz@@@")
+	must(m.Int64Counter("c")).Add(context.TODO(), 1)
 	ctrSnaps := scope.Snapshot().Counters()
 
 	_, ok := ctrSnaps["base.foo.bar.baz.c+"]
 	require.True(t, ok, "counter name should be base.foo.bar.baz.c")
 }
+
+func TestCollectLoop(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("base", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithCollectInterval(10*time.Millisecond),
+		bridge.WithCollectLead(5*time.Millisecond))
+	m := mp.Meter("meter")
+
+	var calls int64
+	must(m.Int64ObservableGauge("g", metric.WithInt64Callback(
+		func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(atomic.AddInt64(&calls, 1))
+			return nil
+		})))
+
+	time.Sleep(30 * time.Millisecond)
+	require.Zero(t, atomic.LoadInt64(&calls), "not started")
+
+	mp.Start()
+	mp.Start() // no effect
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&calls) >= 3
+	}, time.Second, time.Millisecond)
+	mp.Stop()
+
+	stopped := atomic.LoadInt64(&calls)
+	time.Sleep(30 * time.Millisecond)
+	require.Equal(t, stopped, atomic.LoadInt64(&calls), "stopped")
+
+	gsnap, ok := scope.Snapshot().Gauges()["base.meter.g+"]
+	require.True(t, ok)
+	require.EqualValues(t, stopped, gsnap.Value())
+
+	mp.Collect(context.TODO())
+	require.Equal(t, stopped+1, atomic.LoadInt64(&calls))
+}
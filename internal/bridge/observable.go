@@ -0,0 +1,167 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+)
+
+// ErrUnregisteredInstrument is a base error cause reported when an async
+// callback makes an observation for an instrument that it was not registered
+// with.
+var ErrUnregisteredInstrument = errors.New(
+	"observation for instrument not registered with callback")
+
+type (
+	asyncInstrument[N Number] interface {
+		seriesConfigured
+
+		// ObserveOne records a value captured by an async callback for the
+		// provided attributes.
+		ObserveOne(context.Context, attribute.Set, N)
+	}
+
+	// int64Observable is the implementation of the int64 observable
+	// instrument interfaces handed out by a MeterImpl. Observations are
+	// delegated to the bridge instrument appropriate to the instrument kind.
+	int64Observable struct {
+		metric.Int64Observable
+		embedded.Int64ObservableCounter
+		embedded.Int64ObservableUpDownCounter
+		embedded.Int64ObservableGauge
+
+		meter *MeterImpl
+		inst  asyncInstrument[int64]
+	}
+
+	// float64Observable is the float64 counterpart of int64Observable.
+	float64Observable struct {
+		metric.Float64Observable
+		embedded.Float64ObservableCounter
+		embedded.Float64ObservableUpDownCounter
+		embedded.Float64ObservableGauge
+
+		meter *MeterImpl
+		inst  asyncInstrument[float64]
+	}
+
+	// observation holds the state of a single invocation of an async
+	// callback. Observations resolve their Tally series through the
+	// instrument's series cache, as recordings to synchronous instruments do,
+	// so that the tagged scope for an attribute set is built once rather than
+	// on every invocation and the instrument's cardinality limit applies.
+	observation struct {
+		ctx context.Context
+	}
+
+	int64Observer struct {
+		embedded.Int64Observer
+		obs  *observation
+		inst asyncInstrument[int64]
+	}
+
+	float64Observer struct {
+		embedded.Float64Observer
+		obs  *observation
+		inst asyncInstrument[float64]
+	}
+
+	// multiObserver is the metric.Observer passed to callbacks registered via
+	// MeterImpl.RegisterCallback.
+	multiObserver struct {
+		embedded.Observer
+		obs        *observation
+		registered map[metric.Observable]struct{}
+	}
+
+	registration struct {
+		embedded.Registration
+		collector *collector
+		cb        *callback
+	}
+)
+
+// newAsyncInstrument creates the configured instrument bridging desc to Tally.
+// Counter observers share their series with any other counter observer
+// writing to the same Tally name.
+func newAsyncInstrument[N Number](
+	m *MeterImpl,
+	desc Descriptor,
+) asyncInstrument[N] {
+	if desc.InstrumentKind() == ObservableCounterInstrumentKind {
+		c := NewCounterObserver[N](desc, m.scope)
+		c.series = sharedSeries(m, "counter observer", c, c.series)
+		return c
+	}
+	return configured(m, NewGaugeObserver[N](desc, m.scope))
+}
+
+func newObservation(ctx context.Context) *observation {
+	return &observation{ctx: ctx}
+}
+
+// observe writes a value to inst unless the callback making the observation
+// has overrun its deadline, in which case the value is dropped.
+func observe[N Number](
+	o *observation,
+	inst asyncInstrument[N],
+	value N,
+	opts []metric.ObserveOption,
+) {
+	if o.ctx.Err() != nil {
+		return
+	}
+	inst.ObserveOne(o.ctx, metric.NewObserveConfig(opts).Attributes(), value)
+}
+
+// Observe records the value for the instrument this observer was created for.
+func (o *int64Observer) Observe(value int64, opts ...metric.ObserveOption) {
+	observe(o.obs, o.inst, value, opts)
+}
+
+// Observe records the value for the instrument this observer was created for.
+func (o *float64Observer) Observe(value float64, opts ...metric.ObserveOption) {
+	observe(o.obs, o.inst, value, opts)
+}
+
+// ObserveInt64 records the value for the provided instrument, which must be
+// one of the instruments the callback was registered with.
+func (o *multiObserver) ObserveInt64(
+	inst metric.Int64Observable,
+	value int64,
+	opts ...metric.ObserveOption,
+) {
+	if _, ok := o.registered[inst]; !ok {
+		otel.Handle(fmt.Errorf("%w: %T", ErrUnregisteredInstrument, inst))
+		return
+	}
+	observable := inst.(*int64Observable)
+	observe(o.obs, observable.inst, value, opts)
+}
+
+// ObserveFloat64 records the value for the provided instrument, which must be
+// one of the instruments the callback was registered with.
+func (o *multiObserver) ObserveFloat64(
+	inst metric.Float64Observable,
+	value float64,
+	opts ...metric.ObserveOption,
+) {
+	if _, ok := o.registered[inst]; !ok {
+		otel.Handle(fmt.Errorf("%w: %T", ErrUnregisteredInstrument, inst))
+		return
+	}
+	observable := inst.(*float64Observable)
+	observe(o.obs, observable.inst, value, opts)
+}
+
+// Unregister stops the registered callback from being invoked on subsequent
+// collections.
+func (r *registration) Unregister() error {
+	r.collector.unregister(r.cb)
+	return nil
+}
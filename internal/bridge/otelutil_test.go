@@ -23,3 +23,12 @@ func withOTELErrorHandler(h otel.ErrorHandler, f func()) {
 	defer otel.SetErrorHandler(orig)
 	f()
 }
+
+// must unwraps the result of an instrument constructor, failing loudly if an
+// error was returned.
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
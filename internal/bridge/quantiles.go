@@ -0,0 +1,120 @@
+package bridge
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+
+	tally "github.com/uber-go/tally/v4"
+)
+
+// quantileTag is the tag distinguishing the gauges of a quantile summary.
+const quantileTag = "quantile"
+
+// ErrInvalidQuantile is a base error cause returned when a histogram is
+// configured with quantiles outside the range [0, 1].
+var ErrInvalidQuantile = errors.New("invalid quantile")
+
+type (
+	// histogramQuantiles summarizes the values recorded to one histogram as a
+	// set of quantiles per attribute set.
+	histogramQuantiles struct {
+		name      string
+		quantiles []float64
+		tags      []map[string]string
+
+		mu     sync.Mutex
+		series []*quantileSeries
+	}
+
+	// quantileSeries holds the sketch and quantile gauges of one attribute
+	// set of a histogram.
+	quantileSeries struct {
+		gauges []tally.Gauge
+
+		mu     sync.Mutex
+		sketch *sketch
+	}
+)
+
+// newHistogramQuantiles creates a summary of the provided quantiles for the
+// described histogram. Quantiles outside [0, 1] are dropped and reported in
+// an error satisfying errors.Is(err, ErrInvalidQuantile).
+func newHistogramQuantiles(
+	desc Descriptor,
+	quantiles []float64,
+) (*histogramQuantiles, error) {
+	var (
+		valid   []float64
+		invalid []string
+	)
+	for _, q := range quantiles {
+		if q >= 0 && q <= 1 {
+			valid = append(valid, q)
+			continue
+		}
+		invalid = append(invalid, strconv.FormatFloat(q, 'g', -1, 64))
+	}
+	sort.Float64s(valid)
+	h := &histogramQuantiles{
+		name:      desc.Name(),
+		quantiles: valid,
+		tags:      make([]map[string]string, 0, len(valid)),
+	}
+	for _, q := range valid {
+		h.tags = append(h.tags, map[string]string{
+			quantileTag: strconv.FormatFloat(q, 'f', -1, 64),
+		})
+	}
+	if len(invalid) > 0 {
+		return h, fmt.Errorf("%w: %s: %v outside [0, 1] ignored",
+			ErrInvalidQuantile, desc.Name(), invalid)
+	}
+	return h, nil
+}
+
+// newSeries creates the quantile gauges in the provided scope and retains
+// them to be flushed.
+func (h *histogramQuantiles) newSeries(scope tally.Scope) *quantileSeries {
+	s := &quantileSeries{
+		gauges: make([]tally.Gauge, 0, len(h.tags)),
+		sketch: newSketch(),
+	}
+	for _, tags := range h.tags {
+		s.gauges = append(s.gauges, scope.Tagged(tags).Gauge(h.name))
+	}
+	h.mu.Lock()
+	h.series = append(h.series, s)
+	h.mu.Unlock()
+	return s
+}
+
+// record adds a value to the series' sketch.
+func (s *quantileSeries) record(value float64) {
+	s.mu.Lock()
+	s.sketch.add(value)
+	s.mu.Unlock()
+}
+
+// flush publishes the quantiles of the values recorded to each series since
+// the last flush and then resets the series' sketch. Series with no values
+// recorded since the last flush are not updated.
+func (h *histogramQuantiles) flush() {
+	h.mu.Lock()
+	series := h.series
+	h.mu.Unlock()
+	for _, s := range series {
+		s.mu.Lock()
+		values := s.sketch.quantiles(h.quantiles...)
+		s.sketch.reset()
+		s.mu.Unlock()
+		for i, v := range values {
+			if !math.IsNaN(v) {
+				s.gauges[i].Update(v)
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package bridge_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestHistogramQuantiles(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithSelfTelemetryScope(tally.NoopScope),
+		bridge.WithHistogramQuantiles(0.99, 0.5))
+	hist := must(mp.Meter("m").Int64Histogram("h"))
+
+	kvs := []attribute.KeyValue{attribute.String("k", "v")}
+	for i := int64(1); i <= 100; i++ {
+		hist.Record(context.TODO(), i, metric.WithAttributes(kvs...))
+	}
+	hist.Record(context.TODO(), 7)
+	mp.Collect(context.TODO())
+
+	snap := scope.Snapshot()
+	require.Empty(t, snap.Histograms(), "no tally histograms")
+	p50 := append([]attribute.KeyValue{attribute.String("quantile", "0.5")},
+		kvs...)
+	p99 := append([]attribute.KeyValue{attribute.String("quantile", "0.99")},
+		kvs...)
+	require.InEpsilon(t, 50, snap.Gauges()[key("scope.m.h", p50)].Value(), 0.01)
+	require.InEpsilon(t, 99, snap.Gauges()[key("scope.m.h", p99)].Value(), 0.01)
+	require.InEpsilon(t, 7,
+		snap.Gauges()["scope.m.h+quantile=0.99"].Value(), 0.01)
+
+	for i := int64(0); i < 10; i++ {
+		hist.Record(context.TODO(), 1000, metric.WithAttributes(kvs...))
+	}
+	mp.Collect(context.TODO())
+	snap = scope.Snapshot()
+	require.InEpsilon(t, 1000, snap.Gauges()[key("scope.m.h", p50)].Value(),
+		0.01, "sketch reset each interval")
+}
+
+func TestHistogramQuantilesSharedByName(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithSelfTelemetryScope(tally.NoopScope),
+		bridge.WithHistogramQuantiles(1))
+	ints := must(mp.Meter("m").Int64Histogram("h"))
+	floats := must(mp.Meter("m").Float64Histogram("h"))
+
+	ints.Record(context.TODO(), 100)
+	floats.Record(context.TODO(), 1)
+	mp.Collect(context.TODO())
+
+	require.InEpsilon(t, 100,
+		scope.Snapshot().Gauges()["scope.m.h+quantile=1"].Value(), 0.01)
+}
+
+func TestQuantileSelector(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithSelfTelemetryScope(tally.NoopScope),
+		bridge.WithHistogramBucketer(buckets),
+		bridge.WithQuantileSelector(func(desc bridge.Descriptor) []float64 {
+			if desc.Name() == "summary" {
+				return []float64{1.5, 1}
+			}
+			return nil
+		}))
+	m := mp.Meter("m")
+
+	summary, err := m.Float64Histogram("summary")
+	require.True(t, errors.Is(err, bridge.ErrInvalidQuantile))
+	require.NotNil(t, summary)
+	summary.Record(context.TODO(), 3)
+	must(m.Float64Histogram("hist")).Record(context.TODO(), 3)
+	mp.Collect(context.TODO())
+
+	snap := scope.Snapshot()
+	require.Len(t, snap.Histograms(), 1)
+	require.Contains(t, snap.Histograms(), "scope.m.hist+")
+	require.Len(t, snap.Gauges(), 1, "invalid quantile ignored")
+	require.EqualValues(t, 3, snap.Gauges()["scope.m.summary+quantile=1"].Value())
+}
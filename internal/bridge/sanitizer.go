@@ -0,0 +1,368 @@
+package bridge
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"unicode"
+
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel"
+)
+
+// ErrSanitizeCollision is a base error cause reported when a Sanitizer
+// rewrites two different names, tag keys or tag values to the same string.
+var ErrSanitizeCollision = errors.New("sanitized strings collide")
+
+// The kinds of string rewritten by a Sanitizer.
+const (
+	sanitizeName  = "name"
+	sanitizeKey   = "key"
+	sanitizeValue = "value"
+)
+
+// maxCheckedValues bounds the number of distinct tag values a Sanitizer
+// checks for collisions.
+const maxCheckedValues = 10000
+
+var (
+	// prometheusSanitizeOptions match the Prometheus reporter's
+	// DefaultSanitizerOpts.
+	prometheusSanitizeOptions = tally.SanitizeOptions{
+		NameCharacters: tally.ValidCharacters{
+			Ranges:     tally.AlphanumericRange,
+			Characters: tally.UnderscoreCharacters,
+		},
+		KeyCharacters: tally.ValidCharacters{
+			Ranges:     tally.AlphanumericRange,
+			Characters: tally.UnderscoreCharacters,
+		},
+		ValueCharacters: tally.ValidCharacters{
+			Ranges:     tally.AlphanumericRange,
+			Characters: tally.UnderscoreCharacters,
+		},
+		ReplacementCharacter: tally.DefaultReplacementCharacter,
+	}
+
+	// m3SanitizeOptions match the M3 reporter's DefaultSanitizerOpts.
+	m3SanitizeOptions = tally.SanitizeOptions{
+		NameCharacters: tally.ValidCharacters{
+			Ranges:     tally.AlphanumericRange,
+			Characters: tally.UnderscoreDashDotCharacters,
+		},
+		KeyCharacters: tally.ValidCharacters{
+			Ranges:     tally.AlphanumericRange,
+			Characters: tally.UnderscoreDashCharacters,
+		},
+		ValueCharacters: tally.ValidCharacters{
+			Ranges:     tally.AlphanumericRange,
+			Characters: tally.UnderscoreDashDotCharacters,
+		},
+		ReplacementCharacter: tally.DefaultReplacementCharacter,
+	}
+
+	// statsdCharacters are those that cannot break the statsd line protocol,
+	// "<name>:<value>|<type>|@<rate>" with one metric per line: anything but
+	// the ':', '|' and '@' delimiters, spaces and control characters.
+	statsdCharacters = tally.ValidCharacters{
+		Ranges: []tally.SanitizeRange{
+			{'!', '9'},
+			{';', '?'},
+			{'A', '{'},
+			{'}', '~'},
+			{'\u00a1', unicode.MaxRune},
+		},
+	}
+
+	// statsdSanitizeOptions restrict names to statsdCharacters. The Tally
+	// statsd reporter drops tags, so keys and values are restricted alike
+	// only so that collisions between them are still reported.
+	statsdSanitizeOptions = tally.SanitizeOptions{
+		NameCharacters:       statsdCharacters,
+		KeyCharacters:        statsdCharacters,
+		ValueCharacters:      statsdCharacters,
+		ReplacementCharacter: tally.DefaultReplacementCharacter,
+	}
+)
+
+type (
+	// Sanitizer rewrites the sub-scope names, instrument names, tag keys and
+	// tag values passed to Tally by a MeterProvider so that they are
+	// acceptable to a Tally reporter. Strings are rewritten by a
+	// tally.Sanitizer and whenever two different strings that would end up
+	// in the same place are rewritten to the same result the collision is
+	// reported via the OTEL error handler and recorded for Collisions: names
+	// collide within the scope they are created in, tag keys within one tag
+	// map and tag values within the same tag key. Attach a Sanitizer to a
+	// MeterProvider with WithSanitizer.
+	//
+	// Checking for collisions holds every distinct string seen, and the
+	// string it was rewritten to, for the Sanitizer's lifetime. Names and tag
+	// keys come from the instruments and attributes in use and so are few,
+	// but tag values can be unbounded: only the first 10000 distinct tag
+	// values are checked, later ones are rewritten without being checked.
+	// Rewriting a string that has already been checked takes only a read
+	// lock.
+	Sanitizer struct {
+		sanitizer tally.Sanitizer
+
+		mu         sync.RWMutex
+		values     int
+		checked    map[sanitizeEntry]struct{}
+		results    map[sanitizeEntry]string
+		collisions []SanitizeCollision
+		scopes     map[tally.Scope]*sanitizedScope
+	}
+
+	// SanitizeCollision records two different strings that a Sanitizer
+	// rewrote to the same result.
+	SanitizeCollision struct {
+		// Kind is one of "name", "key" or "value".
+		Kind string
+
+		// Key is the sanitized tag key of colliding values. It is empty for
+		// other kinds.
+		Key string
+
+		// Sanitized is the result shared by both strings.
+		Sanitized string
+
+		// First is the string first rewritten to Sanitized.
+		First string
+
+		// Second is the string that later collided with First.
+		Second string
+	}
+
+	// sanitizeEntry identifies a string checked by a Sanitizer. Names are
+	// checked within the untagged scope they are created in, tag values
+	// within their sanitized key.
+	sanitizeEntry struct {
+		scope        tally.Scope
+		kind, key, s string
+	}
+
+	// sanitizedScope is a tally.Scope that sanitizes names and tags before
+	// passing them to the tally.Scope it wraps. Names are checked within
+	// names, the untagged scope that inner was tagged from, as scopes with
+	// different tags still share their names.
+	sanitizedScope struct {
+		s     *Sanitizer
+		inner tally.Scope
+		names tally.Scope
+	}
+)
+
+// NewSanitizer instantiates a Sanitizer that rewrites strings according to
+// the provided tally.SanitizeOptions.
+func NewSanitizer(opts tally.SanitizeOptions) *Sanitizer {
+	return &Sanitizer{
+		sanitizer: tally.NewSanitizer(opts),
+		checked:   make(map[sanitizeEntry]struct{}),
+		results:   make(map[sanitizeEntry]string),
+		scopes:    make(map[tally.Scope]*sanitizedScope),
+	}
+}
+
+// PrometheusSanitizer creates a Sanitizer restricting names, tag keys and tag
+// values to the characters accepted by the Tally Prometheus reporter:
+// letters, digits and underscores.
+func PrometheusSanitizer() *Sanitizer {
+	return NewSanitizer(prometheusSanitizeOptions)
+}
+
+// M3Sanitizer creates a Sanitizer restricting names, tag keys and tag values
+// to the characters accepted by the Tally M3 reporter: letters, digits,
+// underscores, dashes and, except in tag keys, dots.
+func M3Sanitizer() *Sanitizer {
+	return NewSanitizer(m3SanitizeOptions)
+}
+
+// StatsdSanitizer creates a Sanitizer replacing the characters that would
+// break the statsd line protocol used by the Tally statsd reporter: ':', '|',
+// '@', spaces and control characters.
+func StatsdSanitizer() *Sanitizer {
+	return NewSanitizer(statsdSanitizeOptions)
+}
+
+// Name sanitizes a sub-scope or instrument name. Names given to Name collide
+// only with each other, not with the names of the scopes this Sanitizer
+// wraps.
+func (s *Sanitizer) Name(name string) string {
+	return s.name(nil, name)
+}
+
+// Key sanitizes a tag key. Keys only collide with other keys of the same tag
+// map and so are checked only by the scopes this Sanitizer wraps.
+func (s *Sanitizer) Key(key string) string {
+	return s.sanitizer.Key(key)
+}
+
+// Value sanitizes a value of the tag with the provided sanitized key. Values
+// only collide with other values of the same key.
+func (s *Sanitizer) Value(key, value string) string {
+	return s.check(sanitizeEntry{kind: sanitizeValue, key: key, s: value},
+		s.sanitizer.Value(value))
+}
+
+func (s *Sanitizer) name(scope tally.Scope, name string) string {
+	return s.check(sanitizeEntry{scope: scope, kind: sanitizeName, s: name},
+		s.sanitizer.Name(name))
+}
+
+// Collisions lists the collisions caused by this Sanitizer in the order in
+// which they occurred.
+func (s *Sanitizer) Collisions() []SanitizeCollision {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]SanitizeCollision(nil), s.collisions...)
+}
+
+// check records that the entry's string was sanitized to sanitized,
+// reporting a collision if a different string of the same scope, kind and tag
+// key was sanitized to the same result before. Each string is checked only
+// once and tag values only until maxCheckedValues of them have been checked.
+func (s *Sanitizer) check(entry sanitizeEntry, sanitized string) string {
+	s.mu.RLock()
+	done := s.checkedLocked(entry)
+	s.mu.RUnlock()
+	if done {
+		return sanitized
+	}
+
+	s.mu.Lock()
+	if s.checkedLocked(entry) {
+		s.mu.Unlock()
+		return sanitized
+	}
+	s.checked[entry] = struct{}{}
+	if entry.kind == sanitizeValue {
+		s.values++
+	}
+	result := entry
+	result.s = sanitized
+	first, ok := s.results[result]
+	if !ok {
+		s.results[result] = entry.s
+		s.mu.Unlock()
+		return sanitized
+	}
+	s.mu.Unlock()
+	s.report(SanitizeCollision{
+		Kind:      entry.kind,
+		Key:       entry.key,
+		Sanitized: sanitized,
+		First:     first,
+		Second:    entry.s,
+	})
+	return sanitized
+}
+
+// checkKeys reports that the tag keys first and second of one tag map were
+// both sanitized to sanitized, unless the pair has been reported before.
+func (s *Sanitizer) checkKeys(first, second, sanitized string) {
+	entry := sanitizeEntry{kind: sanitizeKey, key: first, s: second}
+	s.mu.Lock()
+	_, done := s.checked[entry]
+	s.checked[entry] = struct{}{}
+	s.mu.Unlock()
+	if !done {
+		s.report(SanitizeCollision{
+			Kind:      sanitizeKey,
+			Sanitized: sanitized,
+			First:     first,
+			Second:    second,
+		})
+	}
+}
+
+// report records a collision and passes it to the OTEL error handler.
+func (s *Sanitizer) report(c SanitizeCollision) {
+	s.mu.Lock()
+	s.collisions = append(s.collisions, c)
+	s.mu.Unlock()
+	what := c.Kind
+	if c.Key != "" {
+		what = fmt.Sprintf("%s of key %q", c.Kind, c.Key)
+	}
+	otel.Handle(fmt.Errorf("%w: %s %q and %q both sanitized to %q",
+		ErrSanitizeCollision, what, c.First, c.Second, c.Sanitized))
+}
+
+// checkedLocked tells whether the entry needs no checking, either because it
+// has been checked before or because the limit on checked values is reached.
+// The caller must hold s.mu.
+func (s *Sanitizer) checkedLocked(entry sanitizeEntry) bool {
+	if entry.kind == sanitizeValue && s.values >= maxCheckedValues {
+		return true
+	}
+	_, ok := s.checked[entry]
+	return ok
+}
+
+// wrap gives the sanitizing tally.Scope wrapping the provided scope, whose
+// names are checked within names. Tally caches sub-scopes and tagged scopes so
+// wrappers are cached too, keeping scope identity stable for the instruments
+// that rely on it.
+func (s *Sanitizer) wrap(inner, names tally.Scope) tally.Scope {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	scope, ok := s.scopes[inner]
+	if !ok {
+		scope = &sanitizedScope{s: s, inner: inner, names: names}
+		s.scopes[inner] = scope
+	}
+	return scope
+}
+
+func (ss *sanitizedScope) Counter(name string) tally.Counter {
+	return ss.inner.Counter(ss.s.name(ss.names, name))
+}
+
+func (ss *sanitizedScope) Gauge(name string) tally.Gauge {
+	return ss.inner.Gauge(ss.s.name(ss.names, name))
+}
+
+func (ss *sanitizedScope) Timer(name string) tally.Timer {
+	return ss.inner.Timer(ss.s.name(ss.names, name))
+}
+
+func (ss *sanitizedScope) Histogram(
+	name string,
+	buckets tally.Buckets,
+) tally.Histogram {
+	return ss.inner.Histogram(ss.s.name(ss.names, name), buckets)
+}
+
+// Tagged sanitizes the tags in the sorted order of their keys so that, of
+// two keys sanitized to the same result, the value of the first is always the
+// one kept.
+func (ss *sanitizedScope) Tagged(tags map[string]string) tally.Scope {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	sanitized := make(map[string]string, len(tags))
+	originals := make(map[string]string, len(tags))
+	for _, k := range keys {
+		key := ss.s.Key(k)
+		if first, ok := originals[key]; ok {
+			ss.s.checkKeys(first, k, key)
+			continue
+		}
+		originals[key] = k
+		sanitized[key] = ss.s.Value(key, tags[k])
+	}
+	return ss.s.wrap(ss.inner.Tagged(sanitized), ss.names)
+}
+
+func (ss *sanitizedScope) SubScope(name string) tally.Scope {
+	sub := ss.inner.SubScope(ss.s.name(ss.names, name))
+	return ss.s.wrap(sub, sub)
+}
+
+func (ss *sanitizedScope) Capabilities() tally.Capabilities {
+	return ss.inner.Capabilities()
+}
@@ -0,0 +1,188 @@
+package bridge_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestSanitizer(t *testing.T) {
+	// not parallel - uses global OTEL error handler
+	sanitizer := bridge.PrometheusSanitizer()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithScopeNameSeparator("/"),
+		bridge.WithSelfTelemetryScope(tally.NoopScope),
+		bridge.WithSanitizer(sanitizer),
+		bridge.WithTimerSelector(func(desc bridge.Descriptor) bool {
+			return desc.Name() == "rpc.duration"
+		}))
+	m := mp.Meter("go.opentelemetry.io/net-http")
+
+	var errs []error
+	withOTELErrorHandler(captureInto(&errs), func() {
+		ctr := must(m.Int64Counter("http.requests"))
+		ctr.Add(context.TODO(), 1, metric.WithAttributes(
+			attribute.Int("http.status_code", 200),
+			attribute.Int("http_status_code", 404),
+			attribute.String("http.route", "/users/{id}")))
+		ctr.Add(context.TODO(), 1, metric.WithAttributes(
+			attribute.Int("http.status_code", 200),
+			attribute.String("http.route", "/users/{id}")))
+		must(m.Float64Histogram("rpc.duration", metric.WithUnit("ms"))).
+			Record(context.TODO(), 1)
+		must(m.Int64ObservableGauge("conns.open",
+			metric.WithInt64Callback(
+				func(_ context.Context, o metric.Int64Observer) error {
+					o.Observe(3, metric.WithAttributes(
+						attribute.String("net.peer", "a:1")))
+					return nil
+				})))
+		mp.Collect(context.TODO())
+	})
+
+	snap := scope.Snapshot()
+	require.EqualValues(t, 2, snap.Counters()[tally.KeyForPrefixedStringMap(
+		"scope.go_opentelemetry_io.net_http.http_requests",
+		map[string]string{
+			"http_status_code": "200",
+			"http_route":       "_users__id_",
+		})].Value())
+	require.Contains(t, snap.Timers(),
+		"scope.go_opentelemetry_io.net_http.rpc_duration+",
+		"selectors see original names")
+	gauge := "scope.go_opentelemetry_io.net_http.conns_open+net_peer=a_1"
+	require.EqualValues(t, 3, snap.Gauges()[gauge].Value())
+
+	require.Len(t, errs, 1)
+	require.True(t, errors.Is(errs[0], bridge.ErrSanitizeCollision))
+	require.Equal(t, []bridge.SanitizeCollision{{
+		Kind:      "key",
+		Sanitized: "http_status_code",
+		First:     "http.status_code",
+		Second:    "http_status_code",
+	}}, sanitizer.Collisions())
+}
+
+func TestSanitizerValueCollisionsPerKey(t *testing.T) {
+	// not parallel - uses global OTEL error handler
+	sanitizer := bridge.PrometheusSanitizer()
+	var errs []error
+	withOTELErrorHandler(captureInto(&errs), func() {
+		require.Equal(t, "a_b", sanitizer.Value("x", "a.b"))
+		require.Equal(t, "a_b", sanitizer.Value("y", "a_b"))
+		require.Equal(t, "a_b", sanitizer.Value("x", "a-b"))
+	})
+
+	require.Len(t, errs, 1)
+	require.Equal(t, []bridge.SanitizeCollision{{
+		Kind:      "value",
+		Key:       "x",
+		Sanitized: "a_b",
+		First:     "a.b",
+		Second:    "a-b",
+	}}, sanitizer.Collisions())
+}
+
+func TestSanitizerValueChecksBounded(t *testing.T) {
+	// not parallel - uses global OTEL error handler
+	sanitizer := bridge.PrometheusSanitizer()
+	for i := 0; i < 10000; i++ {
+		sanitizer.Value("k", strconv.Itoa(i))
+	}
+	withOTELErrorHandler(panicHandler, func() {
+		sanitizer.Value("k", "a.b")
+		sanitizer.Value("k", "a_b")
+	})
+	require.Empty(t, sanitizer.Collisions(), "values past the limit unchecked")
+
+	var errs []error
+	withOTELErrorHandler(captureInto(&errs), func() {
+		sanitizer.Name("a.b")
+		sanitizer.Name("a_b")
+	})
+	require.Len(t, errs, 1, "names always checked")
+}
+
+func TestSanitizerNameCollisionsPerScope(t *testing.T) {
+	// not parallel - uses global OTEL error handler
+	sanitizer := bridge.PrometheusSanitizer()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithSelfTelemetryScope(tally.NoopScope),
+		bridge.WithSanitizer(sanitizer))
+
+	var errs []error
+	withOTELErrorHandler(captureInto(&errs), func() {
+		must(mp.Meter("a").Int64Counter("req.count")).Add(context.TODO(), 1)
+		must(mp.Meter("b").Int64Counter("req_count")).Add(context.TODO(), 1)
+		must(mp.Meter("a").Int64Counter("req.count")).Add(context.TODO(), 1,
+			metric.WithAttributes(attribute.String("k", "v")))
+	})
+	require.Empty(t, errs, "names in different scopes do not collide")
+
+	withOTELErrorHandler(captureInto(&errs), func() {
+		must(mp.Meter("a").Int64Counter("req_count")).Add(context.TODO(), 1,
+			metric.WithAttributes(attribute.String("k", "w")))
+	})
+	require.Len(t, errs, 1, "names in tagged scopes collide")
+	require.Equal(t, []bridge.SanitizeCollision{{
+		Kind:      "name",
+		Sanitized: "req_count",
+		First:     "req.count",
+		Second:    "req_count",
+	}}, sanitizer.Collisions())
+}
+
+func TestSanitizerKeyCollisionsOrdered(t *testing.T) {
+	// not parallel - uses global OTEL error handler
+	sanitizer := bridge.PrometheusSanitizer()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithSelfTelemetryScope(tally.NoopScope),
+		bridge.WithSanitizer(sanitizer))
+	ctr := must(mp.Meter("m").Int64Counter("ctr"))
+
+	var errs []error
+	withOTELErrorHandler(captureInto(&errs), func() {
+		for i := 0; i < 20; i++ {
+			ctr.Add(context.TODO(), 1, metric.WithAttributes(
+				attribute.String("a_b", "second"),
+				attribute.String("a.b", "first"),
+				attribute.Int("n", i)))
+		}
+	})
+
+	require.Len(t, errs, 1, "each pair of keys reported once")
+	for i := 0; i < 20; i++ {
+		name := tally.KeyForPrefixedStringMap("scope.m.ctr",
+			map[string]string{"a_b": "first", "n": strconv.Itoa(i)})
+		require.Contains(t, scope.Snapshot().Counters(), name,
+			"value of the first key in sorted order kept")
+	}
+}
+
+func TestSanitizerPresets(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		sanitizer        *bridge.Sanitizer
+		name, key, value string
+	}{
+		{bridge.PrometheusSanitizer(), "a_b_c_d", "a_b_c_d", "a_b_c_d"},
+		{bridge.M3Sanitizer(), "a.b-c_d", "a_b-c_d", "a.b-c_d"},
+		{bridge.StatsdSanitizer(), "a.b-c_d", "a.b-c_d", "a.b-c_d"},
+	} {
+		require.Equal(t, tc.name, tc.sanitizer.Name("a.b-c:d"))
+		require.Equal(t, tc.key, tc.sanitizer.Key("a.b-c:d"))
+		require.Equal(t, tc.value, tc.sanitizer.Value("k", "a.b-c:d"))
+	}
+	require.Equal(t, "a_b_c_d_/{é}",
+		bridge.StatsdSanitizer().Name("a b|c@d\n/{é}"))
+}
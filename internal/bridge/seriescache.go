@@ -0,0 +1,150 @@
+package bridge
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// overflowTag tags the series into which an instrument's recordings are
+// folded once it has reached its cardinality limit.
+const overflowTag = "otel_metric_overflow"
+
+// ErrCardinalityLimit is a base error cause reported when an instrument
+// exceeds its cardinality limit and begins folding new attribute sets into
+// its overflow series.
+var ErrCardinalityLimit = errors.New("cardinality limit exceeded")
+
+// seriesLimit bounds the number of distinct attribute sets for which a
+// seriesCache creates series. A max of zero or less is unlimited.
+type seriesLimit struct {
+	max         int
+	meter, name string
+	overflows   tally.Counter
+}
+
+// seriesConfig holds the per-instrument settings of a seriesCache.
+type seriesConfig struct {
+	limit seriesLimit
+	view  *attributeView
+}
+
+// seriesConfigured is implemented by the instruments so that a MeterImpl can
+// configure their seriesCache after creating them.
+type seriesConfigured interface {
+	Descriptor() Descriptor
+	configureSeries(seriesConfig)
+}
+
+// maxSeriesAliases bounds the number of incoming attribute sets a seriesCache
+// with an attribute view remembers. A view may drop an attribute of unbounded
+// cardinality, which would otherwise grow the cache without bound even though
+// the series are limited.
+const maxSeriesAliases = 4096
+
+// seriesCache resolves the Tally instrument for each attribute set recorded or
+// observed by one OTEL instrument so that repeated recordings with the same
+// attributes neither build a Tally tag map nor look up a tagged scope in
+// Tally's registry, both of which allocate and lock.
+//
+// Series are created per attribute set as processed by the cache's attribute
+// view, so sets that differ only in attributes the view drops share a series
+// and count once towards the limit. Each incoming attribute set is then
+// mapped to its series so that the view, which allocates, is applied only
+// when an attribute set is first seen.
+//
+// Once the number of series reaches the cache's limit, values recorded with
+// any other attribute set go to a single overflow series tagged with
+// otel_metric_overflow=true. Tally never releases tagged scopes so this
+// bounds the memory held for an instrument recorded with unbounded attributes.
+type seriesCache[T any] struct {
+	base   tally.Scope
+	create func(tally.Scope) T
+	seriesConfig
+
+	mu       sync.RWMutex
+	series   map[attribute.Distinct]T
+	aliases  map[attribute.Distinct]T
+	overflow *T
+}
+
+// newSeriesCache instantiates a seriesCache that calls create with the scope
+// for each new attribute set: the base scope itself for empty sets and a
+// scope tagged with the attributes otherwise.
+func newSeriesCache[T any](
+	base tally.Scope,
+	create func(tally.Scope) T,
+) *seriesCache[T] {
+	return &seriesCache[T]{
+		base:    base,
+		create:  create,
+		series:  make(map[attribute.Distinct]T),
+		aliases: make(map[attribute.Distinct]T),
+	}
+}
+
+// get gives the instrument for the provided attribute set, creating it on
+// first use.
+func (c *seriesCache[T]) get(attrs attribute.Set) T {
+	key := attrs.Equivalent()
+	c.mu.RLock()
+	inst, ok := c.lookup(key)
+	c.mu.RUnlock()
+	if ok {
+		return inst
+	}
+
+	processed := c.view.apply(attrs)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if inst, ok := c.lookup(key); ok {
+		return inst
+	}
+	inst, ok = c.series[processed.Equivalent()]
+	if !ok {
+		if c.limit.max > 0 && len(c.series) >= c.limit.max {
+			return c.overflowSeries()
+		}
+		scope := c.base
+		if processed.Len() > 0 {
+			scope = c.base.Tagged(KVsToTags(processed.ToSlice()))
+		}
+		inst = c.create(scope)
+		c.series[processed.Equivalent()] = inst
+	}
+	if c.view != nil && len(c.aliases) < maxSeriesAliases {
+		c.aliases[key] = inst
+	}
+	return inst
+}
+
+// lookup gives the series already resolved for an incoming attribute set.
+// Without a view, incoming sets are the sets series are created for. It must
+// be called with c.mu held.
+func (c *seriesCache[T]) lookup(key attribute.Distinct) (T, bool) {
+	if c.view == nil {
+		inst, ok := c.series[key]
+		return inst, ok
+	}
+	inst, ok := c.aliases[key]
+	return inst, ok
+}
+
+// overflowSeries gives the overflow series, creating it and reporting that
+// the limit has been reached on first use. It must be called with c.mu held.
+func (c *seriesCache[T]) overflowSeries() T {
+	c.limit.overflows.Inc(1)
+	if c.overflow == nil {
+		inst := c.create(c.base.Tagged(map[string]string{overflowTag: "true"}))
+		c.overflow = &inst
+		otel.Handle(fmt.Errorf(
+			"%w: %s/%s: more than %d attribute sets, folding others into %s",
+			ErrCardinalityLimit, c.limit.meter, c.limit.name, c.limit.max,
+			overflowTag))
+	}
+	return *c.overflow
+}
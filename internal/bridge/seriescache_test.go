@@ -0,0 +1,219 @@
+package bridge_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var benchAttrs = attribute.NewSet(
+	attribute.String("method", "GET"),
+	attribute.String("route", "/users/{id}"),
+	attribute.Int("status", 200),
+)
+
+func TestSeriesCache(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	ctr := bridge.NewCounter(
+		bridge.NewDescriptor("c", bridge.CounterInstrumentKind,
+			bridge.Int64Kind, "", ""),
+		scope)
+
+	ab := metric.WithAttributes(attribute.String("a", "1"),
+		attribute.String("b", "2"))
+	ba := metric.WithAttributes(attribute.String("b", "2"),
+		attribute.String("a", "1"))
+	ctr.Add(context.TODO(), 1, ab)
+	ctr.Add(context.TODO(), 2, ba)
+	ctr.Add(context.TODO(), 4)
+	ctr.Add(context.TODO(), 8, metric.WithAttributeSet(attribute.NewSet()))
+
+	snap := scope.Snapshot().Counters()
+	require.Len(t, snap, 2)
+	require.EqualValues(t, 3, snap["scope.c+a=1,b=2"].Value())
+	require.EqualValues(t, 12, snap["scope.c+"].Value())
+}
+
+func TestSeriesCacheAllocs(t *testing.T) {
+	ctr := bridge.NewCounter(
+		bridge.NewDescriptor("c", bridge.CounterInstrumentKind,
+			bridge.Int64Kind, "", ""),
+		tally.NewTestScope("scope", nil))
+	// the options slice is built up front as the variadic slice would
+	// otherwise be allocated by the caller
+	opts := []metric.AddOption{metric.WithAttributeSet(benchAttrs)}
+	allocs := testing.AllocsPerRun(100, func() {
+		ctr.Add(context.TODO(), 1, opts...)
+	})
+	require.Zero(t, allocs, "cached series recorded without allocation")
+
+	views, err := bridge.NewAttributeViews(bridge.AttributeView{
+		Name: "no-route",
+		Deny: []attribute.Key{"route"},
+	})
+	require.NoError(t, err)
+	viewed := must(bridge.NewMeterProvider(tally.NewTestScope("scope", nil),
+		bridge.WithAttributeViews(views)).Meter("m").Int64Counter("c"))
+	allocs = testing.AllocsPerRun(100, func() {
+		viewed.Add(context.TODO(), 1, opts...)
+	})
+	require.Zero(t, allocs, "view applied only when a set is first seen")
+}
+
+func TestCardinalityLimit(t *testing.T) {
+	// not parallel - uses global OTEL error handler
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithCardinalityLimiter(func(desc bridge.Descriptor) int {
+			if desc.Name() == "unlimited" {
+				return 0
+			}
+			return 2
+		}))
+	m := mp.Meter("m")
+	ctr := must(m.Int64Counter("c"))
+	hist := must(m.Float64Histogram("h"))
+	unlimited := must(m.Int64Counter("unlimited"))
+
+	var errs []error
+	withOTELErrorHandler(captureInto(&errs), func() {
+		for _, user := range []string{"a", "b", "c", "d", "a"} {
+			attrs := metric.WithAttributes(attribute.String("user", user))
+			ctr.Add(context.TODO(), 1, attrs)
+			hist.Record(context.TODO(), 1, attrs)
+			unlimited.Add(context.TODO(), 1, attrs)
+		}
+	})
+	require.Len(t, errs, 2, "reported once per instrument")
+	for _, err := range errs {
+		require.True(t, errors.Is(err, bridge.ErrCardinalityLimit))
+	}
+
+	snap := scope.Snapshot()
+	counters := snap.Counters()
+	require.EqualValues(t, 2, counters["scope.m.c+user=a"].Value())
+	require.EqualValues(t, 1, counters["scope.m.c+user=b"].Value())
+	require.EqualValues(t, 2,
+		counters["scope.m.c+otel_metric_overflow=true"].Value())
+	require.NotContains(t, counters, "scope.m.c+user=c")
+	require.Contains(t, counters, "scope.m.unlimited+user=d")
+	require.Contains(t, snap.Histograms(),
+		"scope.m.h+otel_metric_overflow=true")
+	require.EqualValues(t, 2, counters[tally.KeyForPrefixedStringMap(
+		"scope.tallyotel.cardinality.overflow",
+		map[string]string{"meter": "m", "instrument": "c"})].Value())
+}
+
+func TestCardinalityLimitAsync(t *testing.T) {
+	// not parallel - uses global OTEL error handler
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope,
+		bridge.WithSelfTelemetryScope(tally.NoopScope),
+		bridge.WithCardinalityLimit(2))
+	users := []string{"a", "b", "c"}
+	must(mp.Meter("m").Int64ObservableGauge("g", metric.WithInt64Callback(
+		func(_ context.Context, o metric.Int64Observer) error {
+			for i, user := range users {
+				o.Observe(int64(i),
+					metric.WithAttributes(attribute.String("user", user)))
+			}
+			return nil
+		})))
+
+	var errs []error
+	withOTELErrorHandler(captureInto(&errs), func() {
+		mp.Collect(context.TODO())
+		users = []string{"d"}
+		mp.Collect(context.TODO())
+	})
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], bridge.ErrCardinalityLimit)
+
+	gauges := scope.Snapshot().Gauges()
+	require.Contains(t, gauges, "scope.m.g+user=a")
+	require.Contains(t, gauges, "scope.m.g+user=b")
+	require.NotContains(t, gauges, "scope.m.g+user=c")
+	require.NotContains(t, gauges, "scope.m.g+user=d")
+	require.EqualValues(t, 0,
+		gauges["scope.m.g+otel_metric_overflow=true"].Value())
+}
+
+func BenchmarkCounterAdd(b *testing.B) {
+	m := bridge.NewMeterProvider(tally.NewTestScope("", nil)).Meter("m")
+	ctr := must(m.Int64Counter("c"))
+	opts := []metric.AddOption{metric.WithAttributeSet(benchAttrs)}
+
+	b.Run("NoAttributes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ctr.Add(context.TODO(), 1)
+		}
+	})
+	b.Run("AttributeSet", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ctr.Add(context.TODO(), 1, opts...)
+		}
+	})
+	b.Run("AttributeSetParallel", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				ctr.Add(context.TODO(), 1, opts...)
+			}
+		})
+	})
+	b.Run("Attributes", func(b *testing.B) {
+		kvs := benchAttrs.ToSlice()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ctr.Add(context.TODO(), 1, metric.WithAttributes(kvs...))
+		}
+	})
+}
+
+func BenchmarkHistogramRecord(b *testing.B) {
+	m := bridge.NewMeterProvider(tally.NewTestScope("", nil)).Meter("m")
+	hist := must(m.Float64Histogram("h", metric.WithUnit("ms")))
+	opts := []metric.RecordOption{metric.WithAttributeSet(benchAttrs)}
+
+	b.Run("NoAttributes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			hist.Record(context.TODO(), 12.5)
+		}
+	})
+	b.Run("AttributeSet", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			hist.Record(context.TODO(), 12.5, opts...)
+		}
+	})
+	b.Run("AttributeSetParallel", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				hist.Record(context.TODO(), 12.5, opts...)
+			}
+		})
+	})
+}
+
+// BenchmarkTallyTagged measures resolving a series through Tally on every
+// recording, as the bridge did before caching series, for comparison with
+// the AttributeSet benchmarks above.
+func BenchmarkTallyTagged(b *testing.B) {
+	scope := tally.NewTestScope("", nil)
+	kvs := benchAttrs.ToSlice()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		scope.Tagged(bridge.KVsToTags(kvs)).Counter("c").Inc(1)
+	}
+}
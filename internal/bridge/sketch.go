@@ -0,0 +1,148 @@
+package bridge
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+const (
+	// sketchAccuracy is the relative accuracy of the quantiles estimated by a
+	// sketch.
+	sketchAccuracy = 0.01
+
+	// sketchMaxBins bounds the memory used by each sketch. When exceeded the
+	// bins for the smallest magnitudes are merged, reducing accuracy only for
+	// the lowest quantiles.
+	sketchMaxBins = 2048
+)
+
+var sketchGamma = (1 + sketchAccuracy) / (1 - sketchAccuracy)
+
+type (
+	// sketch is a compact, mergeable summary of a stream of values from which
+	// quantiles can be estimated with bounded relative error. Values are
+	// counted in logarithmically sized bins in the manner of DDSketch.
+	sketch struct {
+		mu       sync.Mutex
+		pos      map[int]uint64
+		neg      map[int]uint64
+		zero     uint64
+		count    uint64
+		sum      float64
+		min, max float64
+	}
+)
+
+func newSketch() *sketch {
+	return &sketch{
+		pos: make(map[int]uint64),
+		neg: make(map[int]uint64),
+		min: math.Inf(1),
+		max: math.Inf(-1),
+	}
+}
+
+func sketchIndex(magnitude float64) int {
+	return int(math.Ceil(math.Log(magnitude) / math.Log(sketchGamma)))
+}
+
+func sketchValue(index int) float64 {
+	return 2 * math.Pow(sketchGamma, float64(index)) / (sketchGamma + 1)
+}
+
+func (s *sketch) add(value float64) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.count++
+	s.sum += value
+	s.min = math.Min(s.min, value)
+	s.max = math.Max(s.max, value)
+	switch {
+	case value > 0:
+		s.pos[sketchIndex(value)]++
+		collapse(s.pos)
+	case value < 0:
+		s.neg[sketchIndex(-value)]++
+		collapse(s.neg)
+	default:
+		s.zero++
+	}
+}
+
+// reset discards all values added to the sketch.
+func (s *sketch) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.pos)
+	clear(s.neg)
+	s.zero, s.count, s.sum = 0, 0, 0
+	s.min, s.max = math.Inf(1), math.Inf(-1)
+}
+
+// collapse merges the bin for the smallest magnitude into the next smallest
+// until the number of bins is within sketchMaxBins.
+func collapse(bins map[int]uint64) {
+	for len(bins) > sketchMaxBins {
+		keys := sortedKeys(bins)
+		bins[keys[1]] += bins[keys[0]]
+		delete(bins, keys[0])
+	}
+}
+
+func sortedKeys(bins map[int]uint64) []int {
+	keys := make([]int, 0, len(bins))
+	for k := range bins {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// quantiles estimates the value at each of the provided quantiles, which must
+// be in ascending order. NaN is returned for each if the sketch is empty.
+func (s *sketch) quantiles(qs ...float64) []float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]float64, len(qs))
+	if s.count == 0 {
+		for i := range out {
+			out[i] = math.NaN()
+		}
+		return out
+	}
+
+	type bin struct {
+		value float64
+		count uint64
+	}
+	bins := make([]bin, 0, len(s.neg)+len(s.pos)+1)
+	negKeys := sortedKeys(s.neg)
+	for i := len(negKeys) - 1; i >= 0; i-- {
+		bins = append(bins, bin{-sketchValue(negKeys[i]), s.neg[negKeys[i]]})
+	}
+	if s.zero > 0 {
+		bins = append(bins, bin{0, s.zero})
+	}
+	for _, k := range sortedKeys(s.pos) {
+		bins = append(bins, bin{sketchValue(k), s.pos[k]})
+	}
+
+	var (
+		seen uint64
+		b    int
+	)
+	for i, q := range qs {
+		rank := uint64(q * float64(s.count-1))
+		for b < len(bins)-1 && seen+bins[b].count <= rank {
+			seen += bins[b].count
+			b++
+		}
+		// estimates are clamped to the exact extremes
+		out[i] = math.Max(s.min, math.Min(s.max, bins[b].value))
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+package bridge
+
+import (
+	"context"
+	"time"
+
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+)
+
+type (
+	// Timer implements the metric.Int64Histogram and metric.Float64Histogram
+	// interfaces (for N of int64 and float64 respectively) for histograms with
+	// a unit of time, bridging between an OTEL Histogram and a Tally Timer.
+	Timer[N Number] struct {
+		embedded.Int64Histogram
+		embedded.Float64Histogram
+
+		desc   Descriptor
+		per    time.Duration
+		series *seriesCache[tally.Timer]
+	}
+)
+
+// NewTimer instantiates a new Timer that uses the provided scope as its base
+// scope. Recorded values are interpreted in the unit of time given by the
+// Descriptor, or as milliseconds if the Descriptor's unit is not a unit of
+// time.
+func NewTimer[N Number](desc Descriptor, scope tally.Scope) *Timer[N] {
+	per, ok := durationUnits[desc.Unit()]
+	if !ok {
+		per = time.Millisecond
+	}
+	return &Timer[N]{
+		desc: desc,
+		per:  per,
+		series: newSeriesCache(scope, func(s tally.Scope) tally.Timer {
+			return s.Timer(desc.Name())
+		}),
+	}
+}
+
+// Descriptor observes this Timer's Descriptor object
+func (t *Timer[N]) Descriptor() Descriptor {
+	return t.desc
+}
+
+// Enabled always returns true as every value is passed to Tally.
+func (t *Timer[N]) Enabled(context.Context) bool {
+	return true
+}
+
+// Record records the provided value as a duration.
+func (t *Timer[N]) Record(
+	ctx context.Context,
+	value N,
+	opts ...metric.RecordOption,
+) {
+	dur := time.Duration(float64(value) * float64(t.per))
+	attrs := metric.NewRecordConfig(opts).Attributes()
+	t.series.get(attrs).Record(dur)
+}
+
+func (t *Timer[N]) configureSeries(cfg seriesConfig) {
+	t.series.seriesConfig = cfg
+}
@@ -0,0 +1,81 @@
+package bridge_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestTimer(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	timer := bridge.NewTimer[float64](
+		bridge.NewDescriptor("t", bridge.HistogramInstrumentKind,
+			bridge.Float64Kind, "", "s"),
+		scope)
+	attrs := []attribute.KeyValue{attribute.Key("foo").String("bar")}
+
+	timer.Record(context.TODO(), 1.5)
+	timer.Record(context.TODO(), 0.25, metric.WithAttributes(attrs...))
+
+	snap := scope.Snapshot().Timers()
+	tsnap, ok := snap["scope.t+"]
+	require.True(t, ok)
+	require.Equal(t, []time.Duration{1500 * time.Millisecond}, tsnap.Values())
+
+	tsnap, ok = snap[key("scope.t", attrs)]
+	require.True(t, ok)
+	require.Equal(t, []time.Duration{250 * time.Millisecond}, tsnap.Values())
+}
+
+func TestDurationHistogramsAsTimers(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope, bridge.WithDurationHistogramsAsTimers())
+	m := mp.Meter("m")
+
+	must(m.Int64Histogram("latency", metric.WithUnit("ms"))).
+		Record(context.TODO(), 20)
+	must(m.Int64Histogram("sizes", metric.WithUnit("By"))).
+		Record(context.TODO(), 20)
+
+	snap := scope.Snapshot()
+	tsnap, ok := snap.Timers()["scope.m.latency+"]
+	require.True(t, ok)
+	require.Equal(t, []time.Duration{20 * time.Millisecond}, tsnap.Values())
+
+	_, ok = snap.Histograms()["scope.m.sizes+"]
+	require.True(t, ok, "non-duration histograms unaffected")
+}
+
+func TestTimerSelector(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope, bridge.WithTimerSelector(
+		func(desc bridge.Descriptor) bool {
+			return desc.Name() == "timed"
+		}))
+	m := mp.Meter("m")
+
+	must(m.Float64Histogram("timed", metric.WithUnit("s"))).
+		Record(context.TODO(), 1)
+	must(m.Float64Histogram("bucketed", metric.WithUnit("s"))).
+		Record(context.TODO(), 1)
+
+	snap := scope.Snapshot()
+	_, ok := snap.Timers()["scope.m.timed+"]
+	require.True(t, ok)
+	_, ok = snap.Histograms()["scope.m.timed+"]
+	require.False(t, ok)
+
+	_, ok = snap.Timers()["scope.m.bucketed+"]
+	require.False(t, ok)
+	_, ok = snap.Histograms()["scope.m.bucketed+"]
+	require.True(t, ok)
+}
@@ -0,0 +1,183 @@
+package bridge
+
+import (
+	"strings"
+	"time"
+
+	tally "github.com/uber-go/tally/v4"
+)
+
+// UCUM unit strings, besides those for time, for which UnitBuckets has a
+// built-in bucket set.
+const (
+	unitBytes         = "By"
+	unitKibibytes     = "KiBy"
+	unitDimensionless = "1"
+)
+
+var (
+	// secondDurationBuckets follow the OTEL semantic convention advice for
+	// request durations.
+	secondDurationBuckets = tally.DurationBuckets{
+		5 * time.Millisecond,
+		10 * time.Millisecond,
+		25 * time.Millisecond,
+		50 * time.Millisecond,
+		75 * time.Millisecond,
+		100 * time.Millisecond,
+		250 * time.Millisecond,
+		500 * time.Millisecond,
+		750 * time.Millisecond,
+		1 * time.Second,
+		2500 * time.Millisecond,
+		5 * time.Second,
+		7500 * time.Millisecond,
+		10 * time.Second,
+	}
+
+	microsecondDurationBuckets = tally.DurationBuckets{
+		0,
+		10 * time.Microsecond,
+		25 * time.Microsecond,
+		50 * time.Microsecond,
+		100 * time.Microsecond,
+		250 * time.Microsecond,
+		500 * time.Microsecond,
+		1 * time.Millisecond,
+		2500 * time.Microsecond,
+		5 * time.Millisecond,
+		10 * time.Millisecond,
+		25 * time.Millisecond,
+		50 * time.Millisecond,
+		100 * time.Millisecond,
+	}
+
+	nanosecondDurationBuckets = tally.DurationBuckets{
+		0,
+		100 * time.Nanosecond,
+		250 * time.Nanosecond,
+		500 * time.Nanosecond,
+		1 * time.Microsecond,
+		2500 * time.Nanosecond,
+		5 * time.Microsecond,
+		10 * time.Microsecond,
+		25 * time.Microsecond,
+		50 * time.Microsecond,
+		100 * time.Microsecond,
+		250 * time.Microsecond,
+		500 * time.Microsecond,
+		1 * time.Millisecond,
+	}
+
+	longDurationBuckets = tally.DurationBuckets{
+		1 * time.Second,
+		5 * time.Second,
+		15 * time.Second,
+		30 * time.Second,
+		1 * time.Minute,
+		2 * time.Minute,
+		5 * time.Minute,
+		10 * time.Minute,
+		15 * time.Minute,
+		30 * time.Minute,
+		1 * time.Hour,
+		2 * time.Hour,
+		6 * time.Hour,
+		12 * time.Hour,
+		24 * time.Hour,
+	}
+
+	// 64B to 64MiB in powers of 4.
+	byteValueBuckets = tally.MustMakeExponentialValueBuckets(64, 4, 11)
+
+	// 1KiB to 64MiB in powers of 4.
+	kibibyteValueBuckets = tally.MustMakeExponentialValueBuckets(1, 4, 9)
+
+	countValueBuckets = tally.ValueBuckets{
+		0, 1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000, 10000,
+	}
+)
+
+// SecondBuckets gives the default buckets for histograms with a unit of
+// seconds, ranging from 5ms to 10s.
+func SecondBuckets() tally.Buckets {
+	return append(tally.DurationBuckets(nil), secondDurationBuckets...)
+}
+
+// MillisecondBuckets gives the default buckets for histograms with a unit of
+// milliseconds, ranging from 0 to 5s.
+func MillisecondBuckets() tally.Buckets {
+	return append(tally.DurationBuckets(nil), defaultDurationBuckets...)
+}
+
+// MicrosecondBuckets gives the default buckets for histograms with a unit of
+// microseconds, ranging from 0 to 100ms.
+func MicrosecondBuckets() tally.Buckets {
+	return append(tally.DurationBuckets(nil), microsecondDurationBuckets...)
+}
+
+// NanosecondBuckets gives the default buckets for histograms with a unit of
+// nanoseconds, ranging from 0 to 1ms.
+func NanosecondBuckets() tally.Buckets {
+	return append(tally.DurationBuckets(nil), nanosecondDurationBuckets...)
+}
+
+// LongDurationBuckets gives the default buckets for histograms with a unit of
+// minutes, hours or days, ranging from 1s to 24h.
+func LongDurationBuckets() tally.Buckets {
+	return append(tally.DurationBuckets(nil), longDurationBuckets...)
+}
+
+// ByteBuckets gives the default buckets for histograms with a unit of bytes,
+// ranging from 64B to 64MiB.
+func ByteBuckets() tally.Buckets {
+	return append(tally.ValueBuckets(nil), byteValueBuckets...)
+}
+
+// KibibyteBuckets gives the default buckets for histograms with a unit of
+// kibibytes, ranging from 1KiB to 64MiB.
+func KibibyteBuckets() tally.Buckets {
+	return append(tally.ValueBuckets(nil), kibibyteValueBuckets...)
+}
+
+// CountBuckets gives the default buckets for dimensionless histograms, ranging
+// from 0 to 10000.
+func CountBuckets() tally.Buckets {
+	return append(tally.ValueBuckets(nil), countValueBuckets...)
+}
+
+// UnitBuckets gives the built-in default buckets for the provided UCUM unit.
+// Histograms with a unit of time record durations and so are given
+// tally.DurationBuckets, other histograms are given tally.ValueBuckets.
+// The second return value is false if there is no built-in bucket set for the
+// unit. The unit "1" and UCUM annotations such as "{request}" are treated as
+// dimensionless counts. The empty unit has no built-in bucket set, so
+// DefaultBucketer gives histograms without a unit its general purpose value
+// buckets.
+func UnitBuckets(unit string) (tally.Buckets, bool) {
+	switch unit {
+	case unitSeconds:
+		return SecondBuckets(), true
+	case unitMilliseconds:
+		return MillisecondBuckets(), true
+	case unitMicroseconds:
+		return MicrosecondBuckets(), true
+	case unitNanoseconds:
+		return NanosecondBuckets(), true
+	case unitMinutes, unitHours, unitDays:
+		return LongDurationBuckets(), true
+	case unitBytes:
+		return ByteBuckets(), true
+	case unitKibibytes:
+		return KibibyteBuckets(), true
+	}
+	if isDimensionless(unit) {
+		return CountBuckets(), true
+	}
+	return nil, false
+}
+
+func isDimensionless(unit string) bool {
+	return unit == unitDimensionless ||
+		(strings.HasPrefix(unit, "{") && strings.HasSuffix(unit, "}"))
+}
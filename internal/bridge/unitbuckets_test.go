@@ -0,0 +1,68 @@
+package bridge_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
+	"github.com/stretchr/testify/require"
+	tally "github.com/uber-go/tally/v4"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestUnitBuckets(t *testing.T) {
+	t.Parallel()
+	for unit, want := range map[string]tally.Buckets{
+		"s":         bridge.SecondBuckets(),
+		"ms":        bridge.MillisecondBuckets(),
+		"us":        bridge.MicrosecondBuckets(),
+		"ns":        bridge.NanosecondBuckets(),
+		"By":        bridge.ByteBuckets(),
+		"KiBy":      bridge.KibibyteBuckets(),
+		"1":         bridge.CountBuckets(),
+		"{request}": bridge.CountBuckets(),
+	} {
+		got, ok := bridge.UnitBuckets(unit)
+		require.True(t, ok, unit)
+		require.Equal(t, want, got, unit)
+	}
+
+	for _, unit := range []string{"Cel", ""} {
+		_, ok := bridge.UnitBuckets(unit)
+		require.False(t, ok, unit)
+	}
+}
+
+func TestDefaultBucketerByUnit(t *testing.T) {
+	t.Parallel()
+	scope := tally.NewTestScope("scope", nil)
+	mp := bridge.NewMeterProvider(scope)
+	m := mp.Meter("m")
+
+	must(m.Int64Histogram("payload", metric.WithUnit("By"))).
+		Record(context.TODO(), 3000)
+	must(m.Int64Histogram("items", metric.WithUnit("{item}"))).
+		Record(context.TODO(), 7)
+	must(m.Float64Histogram("unitless")).Record(context.TODO(), 3)
+
+	snap := scope.Snapshot().Histograms()
+	hsnap, ok := snap["scope.m.payload+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, hsnap.Values()[4096.0])
+
+	hsnap, ok = snap["scope.m.items+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, hsnap.Values()[10.0])
+
+	hsnap, ok = snap["scope.m.unitless+"]
+	require.True(t, ok)
+	require.EqualValues(t, 1, hsnap.Values()[5.0],
+		"no unit keeps the general purpose value buckets")
+}
+
+func TestUnitBucketsAreCopies(t *testing.T) {
+	t.Parallel()
+	b := bridge.ByteBuckets().(tally.ValueBuckets)
+	b[0] = -1
+	require.NotEqual(t, b, bridge.ByteBuckets())
+}
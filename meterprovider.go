@@ -1,7 +1,7 @@
 package tallyotel
 
 import (
-	"github.com/mmcshane/tallyotel/internal/bridge"
+	"github.com/mmcshane/tallyotel/v2/internal/bridge"
 	tally "github.com/uber-go/tally/v4"
 	"go.opentelemetry.io/otel/metric"
 )
@@ -13,12 +13,107 @@ type (
 
 	// HistogramBucketer is an func allowing client code to pick different
 	// bucketization strategies for histograms based on the information in the
-	// histogram's sdkapi.Descriptor.
+	// histogram's Descriptor. It is not consulted for histograms created with
+	// explicit bucket boundary advice.
 	HistogramBucketer = bridge.HistogramBucketer
 
+	// CounterScaler is a func allowing client code to scale the values
+	// recorded to floating point counters, e.g. to count seconds as
+	// milliseconds, before they are accumulated into integral Tally counters.
+	CounterScaler = bridge.CounterScaler
+
+	// TimerSelector is a func allowing client code to choose, per histogram
+	// with a unit of time, whether it is mapped to a tally.Timer rather than a
+	// tally.Histogram.
+	TimerSelector = bridge.TimerSelector
+
+	// QuantileSelector is a func allowing client code to choose, per
+	// histogram, quantiles to be published as Tally gauges in place of a
+	// tally.Histogram.
+	QuantileSelector = bridge.QuantileSelector
+
+	// CardinalityLimiter is a func allowing client code to choose, per
+	// instrument, the maximum number of distinct attribute sets.
+	CardinalityLimiter = bridge.CardinalityLimiter
+
 	// MeterScoper allows clients to override the default behavior of creating a
 	// named Tally sub-scope for each Meter.
 	MeterScoper = bridge.MeterScoper
+
+	// Descriptor describes an instrument created by a Meter. It is passed to
+	// HistogramBucketer and CounterScaler funcs.
+	Descriptor = bridge.Descriptor
+
+	// InstrumentKind identifies the kind of OTEL instrument described by a
+	// Descriptor.
+	InstrumentKind = bridge.InstrumentKind
+
+	// NumberKind identifies whether an OTEL instrument records int64 or
+	// float64 values.
+	NumberKind = bridge.NumberKind
+
+	// BucketRegistry is a declarative alternative to a hand-written
+	// HistogramBucketer. Pass its Bucketer method to WithHistogramBucketer.
+	BucketRegistry = bridge.BucketRegistry
+
+	// BucketRule maps histograms matching Meter and instrument name Patterns
+	// (and optionally a unit) to a BucketSpec.
+	BucketRule = bridge.BucketRule
+
+	// BucketSpec declares the buckets for histograms matching a BucketRule.
+	BucketSpec = bridge.BucketSpec
+
+	// BucketMatch records which BucketRule, if any, gave the buckets for a
+	// histogram.
+	BucketMatch = bridge.BucketMatch
+
+	// BucketAdvisor tracks the values recorded to histograms and reports how
+	// well their buckets fit, suggesting better ones.
+	BucketAdvisor = bridge.BucketAdvisor
+
+	// BucketReport describes the values observed by a histogram, the fit of
+	// its buckets and a suggested bucket layout.
+	BucketReport = bridge.BucketReport
+
+	// CompanionSuffixes names the sum, count, min and max series emitted
+	// alongside histograms when WithHistogramCompanions is used.
+	CompanionSuffixes = bridge.CompanionSuffixes
+
+	// AttributeView filters, maps and renames the attributes of matching
+	// instruments before they are passed to Tally as tags.
+	AttributeView = bridge.AttributeView
+
+	// AttributeViews chooses the first matching AttributeView for each
+	// instrument. Pass it to WithAttributeViews.
+	AttributeViews = bridge.AttributeViews
+
+	// ValueMapper rewrites attribute values in an AttributeView.
+	ValueMapper = bridge.ValueMapper
+
+	// Sanitizer rewrites names, tag keys and tag values so that they are
+	// acceptable to a Tally reporter, reporting any collisions.
+	Sanitizer = bridge.Sanitizer
+
+	// SanitizeCollision records two strings that a Sanitizer rewrote to the
+	// same result.
+	SanitizeCollision = bridge.SanitizeCollision
+
+	// Pattern matches Meter or instrument names in a BucketRule or an
+	// AttributeView.
+	Pattern = bridge.Pattern
+
+	// BoundInt64Counter is a metric.Int64Counter created by this bridge bound
+	// to a fixed attribute set (see BindInt64Counter).
+	BoundInt64Counter = bridge.BoundInt64Counter
+
+	// BoundFloat64Histogram is a metric.Float64Histogram created by this
+	// bridge bound to a fixed attribute set (see BindFloat64Histogram).
+	BoundFloat64Histogram = bridge.BoundFloat64Histogram
+
+	// MeterProvider is a metric.MeterProvider that creates Meters writing to
+	// Tally. It also owns the lifecycle of the periodic collection of values
+	// from asynchronous instruments (see Start, Stop and Collect).
+	MeterProvider = bridge.MeterProvider
 )
 
 var (
@@ -26,24 +121,286 @@ var (
 	// that it can be passed in to a MeterProvider.
 	WithHistogramBucketer = bridge.WithHistogramBucketer
 
+	// WithCounterScaler wraps a CounterScaler into a tallyotel Opt so that it
+	// can be passed in to a MeterProvider.
+	WithCounterScaler = bridge.WithCounterScaler
+
+	// DefaultScaler leaves floating point counter values unscaled. It is
+	// exposed here for use as a fallback within a custom CounterScaler.
+	DefaultScaler = bridge.DefaultScaler
+
+	// WithUpDownCounterAsGauge configures a MeterProvider to map synchronous
+	// UpDownCounters to Tally gauges reporting a running total.
+	WithUpDownCounterAsGauge = bridge.WithUpDownCounterAsGauge
+
+	// WithDurationHistogramsAsTimers configures a MeterProvider to map all
+	// histograms with a unit of time to Tally timers.
+	WithDurationHistogramsAsTimers = bridge.WithDurationHistogramsAsTimers
+
+	// WithTimerSelector wraps a TimerSelector into a tallyotel Opt so that it
+	// can be passed in to a MeterProvider.
+	WithTimerSelector = bridge.WithTimerSelector
+
 	// WithMeterScoper wraps a MeterScoper into a tallyotel Opt so that it can
 	// be passed in to a a MeterProvider.
 	WithMeterScoper = bridge.WithMeterScoper
 
-	// DefaultBucketer returns the default histogram buckets. It is exposed here
-	// for use as a fallback bucketing strategy within a custom
-	// HistogramBucketer.
+	// DefaultBucketer returns the default histogram buckets for a histogram's
+	// unit. It is exposed here for use as a fallback bucketing strategy within
+	// a custom HistogramBucketer.
 	DefaultBucketer = bridge.DefaultBucketer
 
+	// NewBucketRegistry creates a BucketRegistry from an ordered list of
+	// BucketRules and a fallback HistogramBucketer (DefaultBucketer if nil).
+	NewBucketRegistry = bridge.NewBucketRegistry
+
+	// NewBucketAdvisor creates a BucketAdvisor suggesting layouts of the
+	// given number of buckets.
+	NewBucketAdvisor = bridge.NewBucketAdvisor
+
+	// WithBucketAdvisor attaches a BucketAdvisor to a MeterProvider.
+	WithBucketAdvisor = bridge.WithBucketAdvisor
+
+	// WithHistogramCompanions configures a MeterProvider to emit sum, count,
+	// min and max series alongside every histogram.
+	WithHistogramCompanions = bridge.WithHistogramCompanions
+
+	// DefaultCompanionSuffixes gives the suffixes "_sum", "_count", "_min"
+	// and "_max" for use with WithHistogramCompanions.
+	DefaultCompanionSuffixes = bridge.DefaultCompanionSuffixes
+
+	// WithHistogramQuantiles configures a MeterProvider to publish the given
+	// quantiles of every histogram as Tally gauges.
+	WithHistogramQuantiles = bridge.WithHistogramQuantiles
+
+	// WithQuantileSelector wraps a QuantileSelector into a tallyotel Opt so
+	// that it can be passed in to a MeterProvider.
+	WithQuantileSelector = bridge.WithQuantileSelector
+
+	// BindInt64Counter binds a metric.Int64Counter created by this bridge to
+	// an attribute set, returning a handle that adds to the Tally counter for
+	// that set directly.
+	BindInt64Counter = bridge.BindInt64Counter
+
+	// BindFloat64Histogram binds a metric.Float64Histogram created by this
+	// bridge to an attribute set, returning a handle that records to the
+	// Tally histogram or timer for that set directly.
+	BindFloat64Histogram = bridge.BindFloat64Histogram
+
+	// WithCardinalityLimit limits every instrument to a number of distinct
+	// attribute sets, folding further sets into an overflow series.
+	WithCardinalityLimit = bridge.WithCardinalityLimit
+
+	// WithCardinalityLimiter wraps a CardinalityLimiter into a tallyotel Opt
+	// so that it can be passed in to a MeterProvider.
+	WithCardinalityLimiter = bridge.WithCardinalityLimiter
+
+	// NewAttributeViews creates AttributeViews from an ordered list of
+	// AttributeView.
+	NewAttributeViews = bridge.NewAttributeViews
+
+	// WithAttributeViews attaches AttributeViews to a MeterProvider.
+	WithAttributeViews = bridge.WithAttributeViews
+
+	// ValueLookup creates a ValueMapper from a table of replacement values.
+	ValueLookup = bridge.ValueLookup
+
+	// NewSanitizer creates a Sanitizer from tally.SanitizeOptions.
+	NewSanitizer = bridge.NewSanitizer
+
+	// PrometheusSanitizer creates a Sanitizer for the Tally Prometheus
+	// reporter.
+	PrometheusSanitizer = bridge.PrometheusSanitizer
+
+	// M3Sanitizer creates a Sanitizer for the Tally M3 reporter.
+	M3Sanitizer = bridge.M3Sanitizer
+
+	// StatsdSanitizer creates a Sanitizer for the Tally statsd reporter.
+	StatsdSanitizer = bridge.StatsdSanitizer
+
+	// WithSanitizer attaches a Sanitizer to a MeterProvider.
+	WithSanitizer = bridge.WithSanitizer
+
+	// Glob creates a Pattern from a shell-style glob.
+	Glob = bridge.Glob
+
+	// Regexp creates a Pattern from a regular expression.
+	Regexp = bridge.Regexp
+
+	// LinearBuckets declares a BucketSpec of evenly spaced boundaries.
+	LinearBuckets = bridge.LinearBuckets
+
+	// ExponentialBuckets declares a BucketSpec of exponentially spaced
+	// boundaries.
+	ExponentialBuckets = bridge.ExponentialBuckets
+
+	// ExplicitBuckets declares a BucketSpec of the provided boundaries.
+	ExplicitBuckets = bridge.ExplicitBuckets
+
+	// Base2ExponentialBuckets declares a BucketSpec of boundaries aligned
+	// with an OTEL base-2 exponential histogram of a given scale.
+	Base2ExponentialBuckets = bridge.Base2ExponentialBuckets
+
+	// Base2ExponentialBoundaries derives boundaries aligned with an OTEL
+	// base-2 exponential histogram of a given scale, reducing the scale as
+	// needed to stay within a maximum number of buckets.
+	Base2ExponentialBoundaries = bridge.Base2ExponentialBoundaries
+
+	// Base2ExponentialBucketer creates a HistogramBucketer giving histograms
+	// without a unit of time boundaries aligned with an OTEL base-2
+	// exponential histogram.
+	Base2ExponentialBucketer = bridge.Base2ExponentialBucketer
+
+	// Base2ExponentialDurationBucketer creates a HistogramBucketer giving
+	// histograms with a unit of time boundaries aligned with an OTEL base-2
+	// exponential histogram in their unit.
+	Base2ExponentialDurationBucketer = bridge.Base2ExponentialDurationBucketer
+
+	// UnitBuckets gives the built-in default histogram buckets for a UCUM
+	// unit, if there are any. DefaultBucketer uses these.
+	UnitBuckets = bridge.UnitBuckets
+
+	// SecondBuckets gives the default buckets for histograms in seconds.
+	SecondBuckets = bridge.SecondBuckets
+
+	// MillisecondBuckets gives the default buckets for histograms in
+	// milliseconds.
+	MillisecondBuckets = bridge.MillisecondBuckets
+
+	// MicrosecondBuckets gives the default buckets for histograms in
+	// microseconds.
+	MicrosecondBuckets = bridge.MicrosecondBuckets
+
+	// NanosecondBuckets gives the default buckets for histograms in
+	// nanoseconds.
+	NanosecondBuckets = bridge.NanosecondBuckets
+
+	// LongDurationBuckets gives the default buckets for histograms in
+	// minutes, hours or days.
+	LongDurationBuckets = bridge.LongDurationBuckets
+
+	// ByteBuckets gives the default buckets for histograms in bytes.
+	ByteBuckets = bridge.ByteBuckets
+
+	// KibibyteBuckets gives the default buckets for histograms in kibibytes.
+	KibibyteBuckets = bridge.KibibyteBuckets
+
+	// CountBuckets gives the default buckets for dimensionless histograms.
+	CountBuckets = bridge.CountBuckets
+
 	// WithScopeNameSeparator provides a string to a MeterProvider at
 	// construction time to be used in splitting child Meter names into scope
 	// names.
 	WithScopeNameSeparator = bridge.WithScopeNameSeparator
+
+	// WithCollectInterval sets the period on which a started MeterProvider
+	// invokes asynchronous instrument callbacks. This should usually match the
+	// reporting interval of the Tally root scope.
+	WithCollectInterval = bridge.WithCollectInterval
+
+	// WithCollectLead sets how far ahead of each Tally report a started
+	// MeterProvider invokes asynchronous instrument callbacks.
+	WithCollectLead = bridge.WithCollectLead
+
+	// WithCallbackTimeout sets the deadline given to each asynchronous
+	// instrument callback during a collection.
+	WithCallbackTimeout = bridge.WithCallbackTimeout
+
+	// WithSelfTelemetryScope provides the scope to which a MeterProvider
+	// reports metrics about its own operation.
+	WithSelfTelemetryScope = bridge.WithSelfTelemetryScope
+)
+
+var (
+	// ErrUnsupportedInstrument is returned when a Meter is asked to create an
+	// instrument that cannot be mapped to Tally.
+	ErrUnsupportedInstrument = bridge.ErrUnsupportedInstrument
+
+	// ErrNonMonotonicValue is reported when a negative value is added to, or
+	// a decreasing value is observed by, a monotonic counter.
+	ErrNonMonotonicValue = bridge.ErrNonMonotonicValue
+
+	// ErrNonFiniteValue is reported when NaN or an infinite value is added to
+	// a floating point counter.
+	ErrNonFiniteValue = bridge.ErrNonFiniteValue
+
+	// ErrUnbindableInstrument is returned when an attempt is made to bind an
+	// instrument that was not created by this bridge.
+	ErrUnbindableInstrument = bridge.ErrUnbindableInstrument
+
+	// ErrCardinalityLimit is reported when an instrument first exceeds its
+	// cardinality limit.
+	ErrCardinalityLimit = bridge.ErrCardinalityLimit
+
+	// ErrSanitizeCollision is reported when a Sanitizer rewrites two
+	// different strings to the same result.
+	ErrSanitizeCollision = bridge.ErrSanitizeCollision
+
+	// ErrInvalidBuckets is returned, along with a usable instrument, when a
+	// histogram is created with buckets that had to be corrected.
+	ErrInvalidBuckets = bridge.ErrInvalidBuckets
+
+	// ErrInvalidExponentialBuckets is returned when base-2 exponential bucket
+	// boundaries cannot be derived from the given scale, range and limit.
+	ErrInvalidExponentialBuckets = bridge.ErrInvalidExponentialBuckets
+
+	// ErrInvalidQuantile is returned, along with a usable instrument, when a
+	// histogram is configured with quantiles outside [0, 1].
+	ErrInvalidQuantile = bridge.ErrInvalidQuantile
+
+	// ErrInvalidAttributeView is returned by NewAttributeViews when a view
+	// has an invalid pattern.
+	ErrInvalidAttributeView = bridge.ErrInvalidAttributeView
+
+	// ErrInvalidBucketRule is returned by NewBucketRegistry when a rule has an
+	// invalid pattern or bucket spec.
+	ErrInvalidBucketRule = bridge.ErrInvalidBucketRule
+
+	// ErrUnregisteredInstrument is reported when a callback registered via
+	// Meter.RegisterCallback makes an observation for an instrument it was not
+	// registered with.
+	ErrUnregisteredInstrument = bridge.ErrUnregisteredInstrument
+
+	// ErrCallbackPanic is reported when an asynchronous instrument callback
+	// panics.
+	ErrCallbackPanic = bridge.ErrCallbackPanic
+
+	// ErrCallbackTimeout is reported when an asynchronous instrument callback
+	// overruns its deadline.
+	ErrCallbackTimeout = bridge.ErrCallbackTimeout
+)
+
+const (
+	// CounterInstrumentKind indicates a Counter instrument.
+	CounterInstrumentKind = bridge.CounterInstrumentKind
+	// UpDownCounterInstrumentKind indicates an UpDownCounter instrument.
+	UpDownCounterInstrumentKind = bridge.UpDownCounterInstrumentKind
+	// HistogramInstrumentKind indicates a Histogram instrument.
+	HistogramInstrumentKind = bridge.HistogramInstrumentKind
+	// ObservableCounterInstrumentKind indicates an asynchronous Counter
+	// instrument.
+	ObservableCounterInstrumentKind = bridge.ObservableCounterInstrumentKind
+	// ObservableUpDownCounterInstrumentKind indicates an asynchronous
+	// UpDownCounter instrument.
+	ObservableUpDownCounterInstrumentKind = bridge.ObservableUpDownCounterInstrumentKind
+	// ObservableGaugeInstrumentKind indicates an asynchronous Gauge
+	// instrument.
+	ObservableGaugeInstrumentKind = bridge.ObservableGaugeInstrumentKind
+	// GaugeInstrumentKind indicates a synchronous Gauge instrument.
+	GaugeInstrumentKind = bridge.GaugeInstrumentKind
+
+	// Int64Kind indicates an instrument recording int64 values.
+	Int64Kind = bridge.Int64Kind
+	// Float64Kind indicates an instrument recording float64 values.
+	Float64Kind = bridge.Float64Kind
 )
 
 // NewMeterProvider instantiates a tallyotel bridge metric.MeterProvider that
 // uses the supplied tally.Scope as a base scope for the creation of child
-// Meters and Instruments.
-func NewMeterProvider(scope tally.Scope, opts ...Opt) metric.MeterProvider {
+// Meters and Instruments. Call Start on the returned MeterProvider to begin
+// periodic collection from asynchronous instruments.
+func NewMeterProvider(scope tally.Scope, opts ...Opt) *MeterProvider {
 	return bridge.NewMeterProvider(scope, opts...)
 }
+
+var _ metric.MeterProvider = (*MeterProvider)(nil)